@@ -2,9 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -12,6 +15,9 @@ import (
 	"github.com/NethermindEth/starknet-staking-v2/validator"
 	configP "github.com/NethermindEth/starknet-staking-v2/validator/config"
 	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
+	"github.com/NethermindEth/starknet-staking-v2/validator/notify"
+	"github.com/NethermindEth/starknet-staking-v2/validator/statsd"
+	"github.com/NethermindEth/starknet-staking-v2/validator/tracing"
 	"github.com/NethermindEth/starknet-staking-v2/validator/types"
 	"github.com/spf13/cobra"
 )
@@ -26,6 +32,9 @@ Validator program for Starknet stakers created by Nethermind
 
 `
 
+// Commit hash of the build, set at build time through ldflags
+var commit = "unknown"
+
 func NewCommand() cobra.Command {
 	var configPath string
 	var logLevelF string
@@ -33,6 +42,31 @@ func NewCommand() cobra.Command {
 	var metricsF bool
 	var metricsHostF string
 	var metricsPortF string
+	var metricsTLSCertF string
+	var metricsTLSKeyF string
+	var metricsUserF string
+	var metricsPasswordF string
+	var metricsNamespaceF string
+	var metricsSubsystemF string
+	var metricsPushURLF string
+	var metricsPushIntervalF time.Duration
+	var metricsRemoteWriteURLF string
+	var metricsRemoteWriteUserF string
+	var metricsRemoteWritePasswordF string
+	var metricsRemoteWriteIntervalF time.Duration
+	var metricsDisableF []string
+	var metricsDebugEndpointsF bool
+	var metricsLogIntervalF time.Duration
+	var metricsNetworkNameF string
+	var metricsLatencyBucketsF []float64
+	var metricsMedianWindowF int
+	var metricsLabelsF map[string]string
+	var metricsOpenMetricsF bool
+	var telegramBotTokenF string
+	var telegramChatIDF string
+	var tracingEndpointF string
+	var statsdAddressF string
+	var statsdPrefixF string
 	var braavosAccount bool
 
 	var config configP.Config
@@ -92,11 +126,47 @@ func NewCommand() cobra.Command {
 
 		globalCtx := context.Background()
 		var tracer metrics.Tracer = metrics.NewNoOpMetrics()
+		var validatorMetrics *metrics.Metrics
 		if metricsF {
 			// Create metrics server
-			address := fmt.Sprintf("%s:%s", metricsHostF, metricsPortF)
-			metrics := metrics.NewMetrics(address, v.ChainID(), &logger)
-			tracer = metrics
+			address := net.JoinHostPort(metricsHostF, metricsPortF)
+			if strings.HasPrefix(metricsHostF, metrics.UnixSocketPrefix) {
+				address = metricsHostF
+			}
+			validatorMetrics, err = metrics.NewMetricsWithOptions(metrics.Options{
+				Address:                  address,
+				ChainID:                  v.ChainID(),
+				NetworkName:              metricsNetworkNameF,
+				Logger:                   &logger,
+				TLS:                      metrics.TLSFiles{CertFile: metricsTLSCertF, KeyFile: metricsTLSKeyF},
+				Auth:                     metrics.BasicAuth{Username: metricsUserF, Password: metricsPasswordF},
+				Namespace:                metricsNamespaceF,
+				Subsystem:                metricsSubsystemF,
+				Version:                  validator.Version,
+				Commit:                   commit,
+				Disabled:                 metricsDisableF,
+				EnableDebugEndpoints:     metricsDebugEndpointsF,
+				LatencyBuckets:           metricsLatencyBucketsF,
+				MedianConfirmationWindow: metricsMedianWindowF,
+				ConstLabels:              metricsLabelsF,
+				EnableOpenMetrics:        metricsOpenMetricsF,
+			})
+			if errors.Is(err, metrics.ErrRegistration) {
+				// Metrics are not worth missing attestations
+				logger.Errorf("cannot register metrics, attesting without them: %s", err.Error())
+			} else if err != nil {
+				logger.Errorf("cannot start metrics server: %s", err.Error())
+				return
+			}
+		}
+		if validatorMetrics != nil {
+			tracer = validatorMetrics
+			validatorMetrics.SetRPCEndpoint(config.Provider.Http)
+			v.RegisterHealthCheckers(validatorMetrics)
+			if telegramBotTokenF != "" && telegramChatIDF != "" {
+				validatorMetrics.Notifier = notify.NewTelegramNotifier(telegramBotTokenF, telegramChatIDF)
+				logger.Info("Telegram notifications enabled")
+			}
 
 			// Setup signal handling for graceful shutdown
 			ctx, cancel := context.WithCancel(context.Background())
@@ -105,22 +175,83 @@ func NewCommand() cobra.Command {
 
 			// Start metrics server in a goroutine
 			go func() {
-				if err := metrics.Start(); err != nil && err.Error() != "http: Server closed" {
+				if err := validatorMetrics.Start(); err != nil && err.Error() != "http: Server closed" {
 					logger.Errorw("Failed to start metrics server", "error", err)
 				}
 			}()
+			if metricsPushURLF != "" {
+				validatorMetrics.PushURL = metricsPushURLF
+				go func() {
+					if err := validatorMetrics.StartPush(ctx, metricsPushIntervalF); err != nil {
+						logger.Errorw("Failed to push metrics", "error", err)
+					}
+				}()
+			}
+			if metricsRemoteWriteURLF != "" {
+				validatorMetrics.RemoteWriteURL = metricsRemoteWriteURLF
+				validatorMetrics.RemoteWriteAuth = metrics.BasicAuth{
+					Username: metricsRemoteWriteUserF,
+					Password: metricsRemoteWritePasswordF,
+				}
+				go func() {
+					err := validatorMetrics.StartRemoteWrite(ctx, metricsRemoteWriteIntervalF)
+					if err != nil {
+						logger.Errorw("Failed to write metrics", "error", err)
+					}
+				}()
+			}
+			go validatorMetrics.StartSignalDump(ctx)
+			if metricsLogIntervalF > 0 {
+				go func() {
+					if err := validatorMetrics.StartPeriodicLog(ctx, metricsLogIntervalF); err != nil {
+						logger.Errorw("Failed to log metrics summary", "error", err)
+					}
+				}()
+			}
 			// Graceful shutdown at the end
 			defer func() {
-				shutdownCtx, shutdownCancel := context.WithTimeout(
-					context.Background(), 5*time.Second,
-				)
-				defer shutdownCancel()
-				if err := metrics.Stop(shutdownCtx); err != nil {
+				if err := validatorMetrics.Stop(context.Background()); err != nil {
 					logger.Errorw("Failed to stop metrics server", "error", err)
 				}
 			}()
 		}
 
+		if tracingEndpointF != "" {
+			provider, shutdown, err := tracing.NewProvider(
+				globalCtx, tracingEndpointF, validator.Version,
+			)
+			if err != nil {
+				logger.Errorf("cannot start tracing: %s", err.Error())
+				return
+			}
+			tracer = tracing.New(tracer, provider)
+			logger.Infof("Exporting attestation traces to %s", tracingEndpointF)
+			defer func() {
+				if err := shutdown(context.Background()); err != nil {
+					logger.Errorw("Failed to stop tracing", "error", err)
+				}
+			}()
+		}
+
+		if statsdAddressF != "" {
+			network := metricsNetworkNameF
+			if network == "" {
+				network = v.ChainID()
+			}
+			statsdTracer, err := statsd.New(tracer, statsdAddressF, statsdPrefixF, network)
+			if err != nil {
+				logger.Errorf("cannot start statsd: %s", err.Error())
+				return
+			}
+			tracer = statsdTracer
+			logger.Infof("Sending metrics to statsd at %s", statsdAddressF)
+			defer func() {
+				if err := statsdTracer.Close(); err != nil {
+					logger.Errorw("Failed to stop statsd", "error", err)
+				}
+			}()
+		}
+
 		signalCh := make(chan os.Signal, 1)
 		signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM)
 
@@ -179,6 +310,18 @@ func NewCommand() cobra.Command {
 		"",
 		"Signer operational address, required for attesting",
 	)
+	cmd.Flags().StringVar(
+		&config.Signer.RewardsAddress,
+		"signer-rewards-address",
+		"",
+		"Address receiving the staking rewards. When set, its balance is tracked as well",
+	)
+	cmd.Flags().StringVar(
+		&config.Signer.AccountClassHash,
+		"signer-account-class-hash",
+		"",
+		"Class hash the signer account is expected to have. When set, it's checked before attesting",
+	)
 
 	// Config starknet flags
 	cmd.Flags().StringVar(
@@ -196,8 +339,182 @@ func NewCommand() cobra.Command {
 
 	// Metric tracking flags
 	cmd.Flags().BoolVar(&metricsF, "metrics", false, "Enable metric tracking via Prometheus")
-	cmd.Flags().StringVar(&metricsHostF, "metrics-host", "localhost", "Host for the metric server")
+	cmd.Flags().StringVar(
+		&metricsHostF,
+		"metrics-host",
+		"localhost",
+		"Host for the metric server. Use the unix: prefix (e.g. unix:/run/metrics.sock)"+
+			" to listen on a unix domain socket instead, in which case the port is ignored",
+	)
 	cmd.Flags().StringVar(&metricsPortF, "metrics-port", "9090", "Port for the metric server")
+	cmd.Flags().StringVar(
+		&metricsTLSCertF,
+		"metrics-tls-cert",
+		"",
+		"Path to the TLS certificate file. If set together with --metrics-tls-key,"+
+			" the metric server is served over https",
+	)
+	cmd.Flags().StringVar(
+		&metricsTLSKeyF,
+		"metrics-tls-key",
+		"",
+		"Path to the TLS private key file. Required if --metrics-tls-cert is set",
+	)
+	cmd.Flags().StringVar(
+		&metricsUserF,
+		"metrics-user",
+		"",
+		"Username required to access the metrics endpoint through HTTP basic auth",
+	)
+	cmd.Flags().StringVar(
+		&metricsPasswordF,
+		"metrics-password",
+		"",
+		"Password required to access the metrics endpoint through HTTP basic auth",
+	)
+	cmd.Flags().StringVar(
+		&metricsNamespaceF,
+		"metrics-namespace",
+		metrics.DefaultNamespace,
+		"Namespace used as the first part of every metric name",
+	)
+	cmd.Flags().StringVar(
+		&metricsSubsystemF,
+		"metrics-subsystem",
+		metrics.DefaultSubsystem,
+		"Subsystem used as the second part of every metric name",
+	)
+	cmd.Flags().StringVar(
+		&metricsNetworkNameF,
+		"metrics-network-name",
+		"",
+		"Friendly name (e.g. mainnet) used as the network label of every metric."+
+			" Defaults to the chain id",
+	)
+	cmd.Flags().StringVar(
+		&metricsPushURLF,
+		"metrics-push-url",
+		"",
+		"Pushgateway url where metrics are periodically pushed, for when Prometheus"+
+			" cannot scrape the validator",
+	)
+	cmd.Flags().DurationVar(
+		&metricsPushIntervalF,
+		"metrics-push-interval",
+		15*time.Second,
+		"How often metrics are pushed to the pushgateway",
+	)
+	cmd.Flags().StringVar(
+		&metricsRemoteWriteURLF,
+		"metrics-remote-write-url",
+		"",
+		"Prometheus remote write url where metrics are periodically sent, for managed"+
+			" platforms that can neither scrape the validator nor receive pushes",
+	)
+	cmd.Flags().StringVar(
+		&metricsRemoteWriteUserF,
+		"metrics-remote-write-user",
+		"",
+		"Username used to authenticate to the remote write url through HTTP basic auth",
+	)
+	cmd.Flags().StringVar(
+		&metricsRemoteWritePasswordF,
+		"metrics-remote-write-password",
+		"",
+		"Password used to authenticate to the remote write url through HTTP basic auth",
+	)
+	cmd.Flags().DurationVar(
+		&metricsRemoteWriteIntervalF,
+		"metrics-remote-write-interval",
+		15*time.Second,
+		"How often metrics are sent to the remote write url",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&metricsDisableF,
+		"metrics-disable",
+		nil,
+		"Comma separated list of metric names, without namespace and subsystem"+
+			" (e.g. signer_balance), that are not exported",
+	)
+	cmd.Flags().BoolVar(
+		&metricsDebugEndpointsF,
+		"metrics-debug-endpoints",
+		false,
+		"Serve the /debug/reset endpoint, which zeroes every metric on POST."+
+			" Meant for testing only",
+	)
+	cmd.Flags().Float64SliceVar(
+		&metricsLatencyBucketsF,
+		"metrics-latency-buckets",
+		nil,
+		"Comma separated, increasing list of buckets (in seconds) used by the epoch fetch,"+
+			" submission, confirmation and attestation cycle histograms",
+	)
+	cmd.Flags().StringToStringVar(
+		&metricsLabelsF,
+		"metrics-labels",
+		nil,
+		"Comma separated list of key=value labels (e.g. operator=acme) added to every metric",
+	)
+	cmd.Flags().IntVar(
+		&metricsMedianWindowF,
+		"metrics-median-confirmation-window",
+		metrics.DefaultMedianConfirmationWindow,
+		"Number of most recent attestation confirmations the median confirmation time is"+
+			" computed over",
+	)
+	cmd.Flags().BoolVar(
+		&metricsOpenMetricsF,
+		"metrics-openmetrics",
+		false,
+		"Serve the metrics in the OpenMetrics format to the scrapers asking for it,"+
+			" which is required to expose exemplars",
+	)
+	cmd.Flags().DurationVar(
+		&metricsLogIntervalF,
+		"metrics-log-interval",
+		0,
+		"How often a one line summary of the metrics is logged. Disabled when zero",
+	)
+
+	// Notification flags
+	cmd.Flags().StringVar(
+		&telegramBotTokenF,
+		"telegram-bot-token",
+		"",
+		"Telegram bot token used to send alerts. Requires --metrics and --telegram-chat-id",
+	)
+	cmd.Flags().StringVar(
+		&telegramChatIDF,
+		"telegram-chat-id",
+		"",
+		"Telegram chat id where alerts are sent. Requires --metrics and --telegram-bot-token",
+	)
+
+	// Tracing flags
+	cmd.Flags().StringVar(
+		&tracingEndpointF,
+		"tracing-endpoint",
+		"",
+		"OpenTelemetry collector endpoint (e.g. localhost:4318) where attestation traces are"+
+			" exported through OTLP over http. Tracing is disabled when empty",
+	)
+
+	// Statsd flags
+	cmd.Flags().StringVar(
+		&statsdAddressF,
+		"statsd-address",
+		"",
+		"Statsd or DogStatsD server address (e.g. localhost:8125) where the core metrics are"+
+			" sent over udp. Disabled when empty",
+	)
+	cmd.Flags().StringVar(
+		&statsdPrefixF,
+		"statsd-prefix",
+		statsd.DefaultPrefix,
+		"Prefix of every metric name sent to statsd",
+	)
 
 	// Other flags
 	cmd.Flags().StringVar(
@@ -56,6 +56,21 @@ func (mr *MockSignerMockRecorder) Address() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Address", reflect.TypeOf((*MockSigner)(nil).Address))
 }
 
+// BlockNumber mocks base method.
+func (m *MockSigner) BlockNumber() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockNumber")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BlockNumber indicates an expected call of BlockNumber.
+func (mr *MockSignerMockRecorder) BlockNumber() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockNumber", reflect.TypeOf((*MockSigner)(nil).BlockNumber))
+}
+
 // BlockWithTxHashes mocks base method.
 func (m *MockSigner) BlockWithTxHashes(blockID rpc.BlockID) (any, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +116,21 @@ func (mr *MockSignerMockRecorder) Call(call, blockId any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Call", reflect.TypeOf((*MockSigner)(nil).Call), call, blockId)
 }
 
+// ClassHash mocks base method.
+func (m *MockSigner) ClassHash() (*felt.Felt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClassHash")
+	ret0, _ := ret[0].(*felt.Felt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClassHash indicates an expected call of ClassHash.
+func (mr *MockSignerMockRecorder) ClassHash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClassHash", reflect.TypeOf((*MockSigner)(nil).ClassHash))
+}
+
 // EstimateFee mocks base method.
 func (m *MockSigner) EstimateFee(txn *rpc.BroadcastInvokeTxnV3) (rpc.FeeEstimation, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +146,21 @@ func (mr *MockSignerMockRecorder) EstimateFee(txn any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateFee", reflect.TypeOf((*MockSigner)(nil).EstimateFee), txn)
 }
 
+// GetTransactionReceipt mocks base method.
+func (m *MockSigner) GetTransactionReceipt(transactionHash *felt.Felt) (*rpc.TransactionReceiptWithBlockInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionReceipt", transactionHash)
+	ret0, _ := ret[0].(*rpc.TransactionReceiptWithBlockInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionReceipt indicates an expected call of GetTransactionReceipt.
+func (mr *MockSignerMockRecorder) GetTransactionReceipt(transactionHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionReceipt", reflect.TypeOf((*MockSigner)(nil).GetTransactionReceipt), transactionHash)
+}
+
 // GetTransactionStatus mocks base method.
 func (m *MockSigner) GetTransactionStatus(transactionHash *felt.Felt) (*rpc.TxnStatusResult, error) {
 	m.ctrl.T.Helper()
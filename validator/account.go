@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"errors"
+
+	"github.com/NethermindEth/juno/core/felt"
+	junoUtils "github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/starknet-staking-v2/validator/config"
+	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
+	signerP "github.com/NethermindEth/starknet-staking-v2/validator/signer"
+	"github.com/NethermindEth/starknet.go/rpc"
+)
+
+// Checks the signer account is deployed by querying its class hash, which the node refuses
+// for unknown contracts, and that the class hash is the expected one unless it's nil. When
+// the check itself fails, the readiness is left unknown
+func CheckSignerAccount[S signerP.Signer](
+	signer S, expectedClassHash *felt.Felt, logger *junoUtils.ZapLogger, tracer metrics.Tracer,
+) {
+	classHash, err := signer.ClassHash()
+	if err != nil {
+		var rpcErr *rpc.RPCError
+		if errors.As(err, &rpcErr) && rpcErr.Code == rpc.ErrContractNotFound.Code {
+			logger.Errorf(
+				"Signer account %s is not deployed, attestations will fail", signer.Address(),
+			)
+			tracer.UpdateSignerAccountReady(false)
+			return
+		}
+		logger.Warnf("Unable to check signer account %s: %s", signer.Address(), err.Error())
+		return
+	}
+
+	if expectedClassHash != nil && !classHash.Equal(expectedClassHash) {
+		logger.Errorf(
+			"Signer account %s has class hash %s instead of %s, attestations will fail",
+			signer.Address(),
+			classHash,
+			expectedClassHash,
+		)
+		tracer.UpdateSignerAccountReady(false)
+		return
+	}
+	logger.Debugf("Signer account %s is deployed with class hash %s", signer.Address(), classHash)
+	tracer.UpdateSignerAccountReady(true)
+}
+
+// Checks whether the validator can attest, which it can't while the staking contract is
+// paused. When the check itself fails, the state is left unknown
+func CheckValidatorActive[S signerP.Signer](
+	signer S, logger *junoUtils.ZapLogger, tracer metrics.Tracer,
+) {
+	paused, err := signerP.FetchStakingPaused(signer)
+	if err != nil {
+		logger.Warnf("Unable to check whether the staking contract is paused: %s", err.Error())
+		return
+	}
+	if paused {
+		logger.Errorf("Staking contract is paused, attestations will fail")
+	}
+	tracer.UpdateValidatorActive(!paused)
+}
+
+// Checks the node runs the network the staking contract belongs to, catching e.g. mainnet
+// contracts used against a sepolia node. A staking contract that isn't the default one of a
+// known network can't be checked, so the mismatch is left unknown
+func CheckChainID[S signerP.Signer](
+	signer S, nodeChainID string, logger *junoUtils.ZapLogger, tracer metrics.Tracer,
+) {
+	staking := signer.ValidationContracts().Staking
+	expected := config.ChainIDOfStakingContract(staking.Felt())
+	if expected == "" {
+		logger.Debugf("Unable to check the chain id of staking contract %s", staking.String())
+		return
+	}
+
+	mismatch := expected != nodeChainID
+	if mismatch {
+		logger.Errorf(
+			"Node chain id is %s but the staking contract belongs to %s, attestations will fail",
+			nodeChainID,
+			expected,
+		)
+	}
+	tracer.UpdateChainIdMismatch(mismatch)
+}
@@ -0,0 +1,180 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/starknet-staking-v2/mocks"
+	"github.com/NethermindEth/starknet-staking-v2/validator"
+	"github.com/NethermindEth/starknet-staking-v2/validator/constants"
+	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
+	"github.com/NethermindEth/starknet-staking-v2/validator/types"
+	"github.com/NethermindEth/starknet.go/rpc"
+	snGoUtils "github.com/NethermindEth/starknet.go/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type accountTracer struct {
+	metrics.NoOpMetrics
+	ready    []bool
+	active   []bool
+	mismatch []bool
+}
+
+func (a *accountTracer) UpdateSignerAccountReady(ready bool) {
+	a.ready = append(a.ready, ready)
+}
+
+func (a *accountTracer) UpdateValidatorActive(active bool) {
+	a.active = append(a.active, active)
+}
+
+func (a *accountTracer) UpdateChainIdMismatch(mismatch bool) {
+	a.mismatch = append(a.mismatch, mismatch)
+}
+
+func TestCheckSignerAccount(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockSigner := mocks.NewMockSigner(mockCtrl)
+	mockSigner.EXPECT().Address().Return(&types.Address{}).AnyTimes()
+	logger := utils.NewNopZapLogger()
+
+	classHash := utils.HexToFelt(t, "0x123")
+
+	t.Run("deployed account is ready", func(t *testing.T) {
+		tracer := &accountTracer{}
+		mockSigner.EXPECT().ClassHash().Return(classHash, nil)
+
+		validator.CheckSignerAccount(mockSigner, nil, logger, tracer)
+
+		require.Equal(t, []bool{true}, tracer.ready)
+	})
+
+	t.Run("account with the expected class hash is ready", func(t *testing.T) {
+		tracer := &accountTracer{}
+		mockSigner.EXPECT().ClassHash().Return(classHash, nil)
+
+		validator.CheckSignerAccount(mockSigner, utils.HexToFelt(t, "0x123"), logger, tracer)
+
+		require.Equal(t, []bool{true}, tracer.ready)
+	})
+
+	t.Run("account with another class hash is not ready", func(t *testing.T) {
+		tracer := &accountTracer{}
+		mockSigner.EXPECT().ClassHash().Return(classHash, nil)
+
+		validator.CheckSignerAccount(mockSigner, utils.HexToFelt(t, "0x456"), logger, tracer)
+
+		require.Equal(t, []bool{false}, tracer.ready)
+	})
+
+	t.Run("undeployed account is not ready", func(t *testing.T) {
+		tracer := &accountTracer{}
+		mockSigner.EXPECT().ClassHash().Return(nil, rpc.ErrContractNotFound)
+
+		validator.CheckSignerAccount(mockSigner, nil, logger, tracer)
+
+		require.Equal(t, []bool{false}, tracer.ready)
+	})
+
+	t.Run("readiness is unknown when the check fails", func(t *testing.T) {
+		tracer := &accountTracer{}
+		mockSigner.EXPECT().ClassHash().Return(nil, errors.New("connection refused"))
+
+		validator.CheckSignerAccount(mockSigner, nil, logger, tracer)
+
+		require.Empty(t, tracer.ready)
+	})
+}
+
+func TestCheckValidatorActive(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockSigner := mocks.NewMockSigner(mockCtrl)
+	mockSigner.EXPECT().ValidationContracts().Return(
+		validator.SepoliaValidationContracts(t),
+	).AnyTimes()
+	logger := utils.NewNopZapLogger()
+	isPaused := rpc.FunctionCall{
+		ContractAddress:    utils.HexToFelt(t, constants.SEPOLIA_STAKING_CONTRACT_ADDRESS),
+		EntryPointSelector: snGoUtils.GetSelectorFromNameFelt("is_paused"),
+		Calldata:           []*felt.Felt{},
+	}
+
+	t.Run("validator is active while staking is not paused", func(t *testing.T) {
+		tracer := &accountTracer{}
+		mockSigner.EXPECT().
+			Call(isPaused, rpc.BlockID{Tag: "latest"}).
+			Return([]*felt.Felt{new(felt.Felt)}, nil)
+
+		validator.CheckValidatorActive(mockSigner, logger, tracer)
+
+		require.Equal(t, []bool{true}, tracer.active)
+	})
+
+	t.Run("validator is not active while staking is paused", func(t *testing.T) {
+		tracer := &accountTracer{}
+		mockSigner.EXPECT().
+			Call(isPaused, rpc.BlockID{Tag: "latest"}).
+			Return([]*felt.Felt{new(felt.Felt).SetUint64(1)}, nil)
+
+		validator.CheckValidatorActive(mockSigner, logger, tracer)
+
+		require.Equal(t, []bool{false}, tracer.active)
+	})
+
+	t.Run("state is unknown when the check fails", func(t *testing.T) {
+		tracer := &accountTracer{}
+		mockSigner.EXPECT().
+			Call(isPaused, rpc.BlockID{Tag: "latest"}).
+			Return(nil, errors.New("some contract error"))
+
+		validator.CheckValidatorActive(mockSigner, logger, tracer)
+
+		require.Empty(t, tracer.active)
+	})
+}
+
+func TestCheckChainID(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockSigner := mocks.NewMockSigner(mockCtrl)
+	logger := utils.NewNopZapLogger()
+
+	t.Run("node runs the network of the staking contract", func(t *testing.T) {
+		tracer := &accountTracer{}
+		mockSigner.EXPECT().ValidationContracts().Return(validator.SepoliaValidationContracts(t))
+
+		validator.CheckChainID(mockSigner, "SN_SEPOLIA", logger, tracer)
+
+		require.Equal(t, []bool{false}, tracer.mismatch)
+	})
+
+	t.Run("node runs another network than the staking contract", func(t *testing.T) {
+		tracer := &accountTracer{}
+		mockSigner.EXPECT().ValidationContracts().Return(validator.SepoliaValidationContracts(t))
+
+		validator.CheckChainID(mockSigner, "SN_MAIN", logger, tracer)
+
+		require.Equal(t, []bool{true}, tracer.mismatch)
+	})
+
+	t.Run("mismatch is unknown for a custom staking contract", func(t *testing.T) {
+		tracer := &accountTracer{}
+		mockSigner.EXPECT().ValidationContracts().Return(&types.ValidationContracts{
+			Staking: types.AddressFromString("0x123"),
+			Attest:  types.AddressFromString("0x456"),
+		})
+
+		validator.CheckChainID(mockSigner, "SN_SEPOLIA", logger, tracer)
+
+		require.Empty(t, tracer.mismatch)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	junoUtils "github.com/NethermindEth/juno/utils"
 	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
 	signerP "github.com/NethermindEth/starknet-staking-v2/validator/signer"
+	"github.com/NethermindEth/starknet-staking-v2/validator/types"
 )
 
 func CheckBalance[S signerP.Signer](
@@ -14,16 +15,47 @@ func CheckBalance[S signerP.Signer](
 	// call the stark token balance based on the signer address
 	// record the balance
 	// give a warning if below certain threshold (optional)
-	logger.Debugf("Calling balance of %s", signer.Address())
-	balanceWei, err := signerP.FetchValidatorBalance(signer)
-	if err != nil {
-		logger.Warnf("Unable to get STRK balance of account %s: %s", signer.Address(), err.Error())
+	balance, ok := fetchBalance(signer, signer.Address(), logger)
+	if !ok {
+		return
+	}
+	address := signer.Address().String()
+	tracer.UpdateSignerBalance(address, metrics.AddressRoleOperational, balance)
+
+	if balance <= threshold {
+		logger.Warnf("Balance below threshold: %f <= %f", balance, threshold)
+		tracer.RecordSignerBalanceBelowThreshold(address, metrics.AddressRoleOperational)
+	} else {
+		tracer.RecordSignerBalanceAboveThreshold(address, metrics.AddressRoleOperational)
+	}
+}
+
+// Records the balance of the account receiving the staking rewards. It doesn't pay for the
+// attestations, so unlike the signer balance it isn't compared to the threshold
+func CheckRewardsBalance[S signerP.Signer](
+	signer S, address *types.Address, logger *junoUtils.ZapLogger, tracer metrics.Tracer,
+) {
+	balance, ok := fetchBalance(signer, address, logger)
+	if !ok {
 		return
 	}
+	tracer.UpdateSignerBalance(address.String(), metrics.AddressRoleRewards, balance)
+}
+
+// Returns the STRK balance of the account, or false if it can't be read or represented
+func fetchBalance[S signerP.Signer](
+	signer S, address *types.Address, logger *junoUtils.ZapLogger,
+) (float64, bool) {
+	logger.Debugf("Calling balance of %s", address)
+	balanceWei, err := signerP.FetchBalance(signer, address)
+	if err != nil {
+		logger.Warnf("Unable to get STRK balance of account %s: %s", address, err.Error())
+		return 0, false
+	}
 	balance := balanceWei.Strk()
 	logger.Infow(
 		"Account balance",
-		"address", signer.Address(),
+		"address", address,
 		"STRK", balance,
 		"WEI", balanceWei.Text(10),
 	)
@@ -39,14 +71,7 @@ func CheckBalance[S signerP.Signer](
 			balanceWei.Text(10),
 			balance,
 		)
-		return
-	}
-	tracer.UpdateSignerBalance(balance)
-
-	if balance <= threshold {
-		logger.Warnf("Balance below threshold: %f <= %f", balance, threshold)
-		tracer.RecordSignerBalanceBelowThreshold()
-	} else {
-		tracer.RecordSignerBalanceAboveThreshold()
+		return 0, false
 	}
+	return balance, true
 }
@@ -0,0 +1,66 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/starknet-staking-v2/mocks"
+	"github.com/NethermindEth/starknet-staking-v2/validator"
+	"github.com/NethermindEth/starknet-staking-v2/validator/constants"
+	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
+	"github.com/NethermindEth/starknet-staking-v2/validator/types"
+	"github.com/NethermindEth/starknet.go/rpc"
+	snGoUtils "github.com/NethermindEth/starknet.go/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type balanceTracer struct {
+	metrics.NoOpMetrics
+	roles    []string
+	balances []float64
+}
+
+func (b *balanceTracer) UpdateSignerBalance(address string, role string, balance float64) {
+	b.roles = append(b.roles, role)
+	b.balances = append(b.balances, balance)
+}
+
+func TestCheckRewardsBalance(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockSigner := mocks.NewMockSigner(mockCtrl)
+	logger := utils.NewNopZapLogger()
+	rewardsAddress := types.AddressFromString("0x789")
+	balanceOf := rpc.FunctionCall{
+		ContractAddress:    utils.HexToFelt(t, constants.STRK_CONTRACT_ADDRESS),
+		EntryPointSelector: snGoUtils.GetSelectorFromNameFelt("balance_of"),
+		Calldata:           []*felt.Felt{rewardsAddress.Felt()},
+	}
+
+	t.Run("balance is recorded with the rewards role", func(t *testing.T) {
+		tracer := &balanceTracer{}
+		mockSigner.EXPECT().
+			Call(balanceOf, rpc.BlockID{Tag: "latest"}).
+			Return([]*felt.Felt{new(felt.Felt).SetUint64(5e18), new(felt.Felt)}, nil)
+
+		validator.CheckRewardsBalance(mockSigner, &rewardsAddress, logger, tracer)
+
+		require.Equal(t, []string{metrics.AddressRoleRewards}, tracer.roles)
+		require.Equal(t, []float64{5}, tracer.balances)
+	})
+
+	t.Run("balance is unknown when it can't be read", func(t *testing.T) {
+		tracer := &balanceTracer{}
+		mockSigner.EXPECT().
+			Call(balanceOf, rpc.BlockID{Tag: "latest"}).
+			Return(nil, errors.New("some contract error"))
+
+		validator.CheckRewardsBalance(mockSigner, &rewardsAddress, logger, tracer)
+
+		require.Empty(t, tracer.balances)
+	})
+}
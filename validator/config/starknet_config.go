@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/starknet-staking-v2/validator/constants"
 )
 
@@ -40,6 +41,29 @@ func chainIDFromStr(s string) chainID {
 	}
 }
 
+func (c chainID) String() string {
+	switch c {
+	case mainnet:
+		return "SN_MAIN"
+	case sepolia:
+		return "SN_SEPOLIA"
+	default:
+		return ""
+	}
+}
+
+// Returns the chain id of the known network where the given staking contract is deployed
+// by default, or an empty string if it isn't the default one of any known network
+func ChainIDOfStakingContract(staking *felt.Felt) string {
+	for i, addresses := range defaults {
+		address, err := new(felt.Felt).SetString(addresses.Staking)
+		if err == nil && address.Equal(staking) {
+			return chainID(i).String()
+		}
+	}
+	return ""
+}
+
 type ContractAddresses struct {
 	Staking string
 	Attest  string
@@ -3,7 +3,10 @@ package config
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+
+	"github.com/NethermindEth/juno/core/felt"
 )
 
 type Provider struct {
@@ -42,12 +45,23 @@ type Signer struct {
 	ExternalURL        string `json:"url"`
 	PrivKey            string `json:"privateKey"`
 	OperationalAddress string `json:"operationalAddress"`
+	// Optional, the balance of the account receiving the staking rewards is only tracked
+	// when set
+	RewardsAddress string `json:"rewardsAddress"`
+	// Optional, the class hash the signer account is expected to have. Only the deployment
+	// of the account is checked when not set
+	AccountClassHash string `json:"accountClassHash"`
 }
 
 func (s *Signer) Check() error {
 	if s.OperationalAddress == "" {
 		return errors.New("operational address is not set in signer configuration")
 	}
+	if s.AccountClassHash != "" {
+		if _, err := new(felt.Felt).SetString(s.AccountClassHash); err != nil {
+			return fmt.Errorf("invalid account class hash in signer configuration: %w", err)
+		}
+	}
 	if s.External() {
 		return nil
 	}
@@ -62,6 +76,8 @@ func SignerFromEnv() Signer {
 		ExternalURL:        os.Getenv("SIGNER_EXTERNAL_URL"),
 		PrivKey:            os.Getenv("SIGNER_PRIVATE_KEY"),
 		OperationalAddress: os.Getenv("SIGNER_OPERATIONAL_ADDRESS"),
+		RewardsAddress:     os.Getenv("SIGNER_REWARDS_ADDRESS"),
+		AccountClassHash:   os.Getenv("SIGNER_ACCOUNT_CLASS_HASH"),
 	}
 }
 
@@ -76,6 +92,12 @@ func (s *Signer) Fill(other *Signer) {
 	if isZero(s.OperationalAddress) {
 		s.OperationalAddress = other.OperationalAddress
 	}
+	if isZero(s.RewardsAddress) {
+		s.RewardsAddress = other.RewardsAddress
+	}
+	if isZero(s.AccountClassHash) {
+		s.AccountClassHash = other.AccountClassHash
+	}
 }
 
 func (s *Signer) External() bool {
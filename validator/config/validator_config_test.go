@@ -95,12 +95,18 @@ func TestConfigFromEnv(t *testing.T) {
 	t.Setenv("SIGNER_PRIVATE_KEY", privateKey)
 	operationalAddress := "hallo"
 	t.Setenv("SIGNER_OPERATIONAL_ADDRESS", operationalAddress)
+	rewardsAddress := "hola"
+	t.Setenv("SIGNER_REWARDS_ADDRESS", rewardsAddress)
+	accountClassHash := "0xabc"
+	t.Setenv("SIGNER_ACCOUNT_CLASS_HASH", accountClassHash)
 
 	signer := SignerFromEnv()
 	expectedSigner := Signer{
 		ExternalURL:        url,
 		PrivKey:            privateKey,
 		OperationalAddress: operationalAddress,
+		RewardsAddress:     rewardsAddress,
+		AccountClassHash:   accountClassHash,
 	}
 	require.Equal(
 		t,
@@ -214,6 +220,23 @@ func TestCorrectConfig(t *testing.T) {
 		require.NoError(t, err)
 		require.ErrorContains(t, config.Check(), "private key")
 	})
+
+	t.Run("Invalid account class hash", func(t *testing.T) {
+		data := []byte(`{
+            "provider": {
+                "http": "http://localhost:1234",
+                "ws": "ws://localhost:1235"
+            },
+            "signer": {
+                "privateKey": "0x123",
+                "operationalAddress": "0x456",
+                "accountClassHash": "not a felt"
+            }
+        }`)
+		config, err := FromData(data)
+		require.NoError(t, err)
+		require.ErrorContains(t, config.Check(), "account class hash")
+	})
 }
 
 func TestConfigFill(t *testing.T) {
@@ -237,7 +260,8 @@ func TestConfigFill(t *testing.T) {
             },
             "signer": {
                 "url": "http://localhost:5678",
-                "privateKey": "0x999"
+                "privateKey": "0x999",
+                "rewardsAddress": "0x789"
             }
         }`),
 	)
@@ -253,7 +277,8 @@ func TestConfigFill(t *testing.T) {
             "signer": {
                 "url": "http://localhost:5678",
                 "privateKey": "0x123", 
-                "operationalAddress": "0x456"
+                "operationalAddress": "0x456",
+                "rewardsAddress": "0x789"
             }
         }`),
 	)
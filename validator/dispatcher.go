@@ -1,8 +1,11 @@
 package validator
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
@@ -30,9 +33,62 @@ const (
 	Failed
 )
 
+// Reasons reported when an attestation window ends without a successful attestation
+const (
+	ReasonBuildFailed       = "build_failed"
+	ReasonNonceUpdateFailed = "nonce_update_failed"
+	ReasonInvokeFailed      = "invoke_failed"
+	// The node refused the attest transaction, e.g. because of a bad nonce or low fee
+	ReasonRPCRejected = "rpc_rejected"
+	// The node refused the attest transaction since the signer balance can't cover its fee
+	ReasonInsufficientFunds = "insufficient_funds"
+	// The node could not be reached while invoking the attest transaction
+	ReasonNetworkError = "network_error"
+	// The node didn't answer in time while invoking the attest transaction
+	ReasonTimeout      = "timeout"
+	ReasonTxnFailed    = "transaction_failed"
+	ReasonNotConfirmed = "not_confirmed"
+	ReasonNotSubmitted = "not_submitted"
+)
+
+// Tells apart why invoking the attest transaction failed: the node rejected it, the node
+// could not be reached or it didn't answer in time
+func InvokeFailureReason(err error) string {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout() {
+		return ReasonTimeout
+	}
+	if netErr != nil {
+		return ReasonNetworkError
+	}
+
+	var rpcErr *rpc.RPCError
+	if !errors.As(err, &rpcErr) {
+		return ReasonInvokeFailed
+	}
+	// Any error that is not an answer from the node, e.g. a dropped connection, is wrapped
+	// by starknet.go into an internal error
+	if rpcErr.Code == rpc.ErrInsufficientAccountBalance.Code {
+		return ReasonInsufficientFunds
+	}
+	if rpcErr.Code != rpc.InternalError {
+		return ReasonRPCRejected
+	}
+	message := strings.ToLower(rpcErr.Error())
+	if strings.Contains(message, "timeout") || strings.Contains(message, "deadline exceeded") {
+		return ReasonTimeout
+	}
+	return ReasonNetworkError
+}
+
 type AttestTransaction struct {
 	txn   rpc.BroadcastInvokeTxnV3
 	valid bool
+	// L2 gas price and unit of the last fee estimation, and the highest L2 gas price the
+	// transaction accepts to pay after it
+	gasPrice    *felt.Felt
+	feeUnit     rpc.FeePaymentUnit
+	maxGasPrice *felt.Felt
 }
 
 func (t *AttestTransaction) Build(signer signerP.Signer, blockHash *types.BlockHash) error {
@@ -66,7 +122,13 @@ func (t *AttestTransaction) Invoke(signer signerP.Signer) (
 	if err != nil {
 		return nil, err
 	}
+	t.gasPrice = estimate.L2GasPrice
+	t.feeUnit = estimate.FeeUnit
 	t.txn.ResourceBounds = utils.FeeEstToResBoundsMap(estimate, 1.5)
+	t.maxGasPrice, err = new(felt.Felt).SetString(string(t.txn.ResourceBounds.L2Gas.MaxPricePerUnit))
+	if err != nil {
+		t.maxGasPrice = nil
+	}
 
 	// patch for making sure txn.Version is correct
 	t.txn.Version = rpc.TransactionV3
@@ -96,6 +158,51 @@ func (t *AttestTransaction) UpdateNonce(signer signerP.Signer) error {
 	return nil
 }
 
+// Returns the nonce the transaction was last signed with
+func (t *AttestTransaction) Nonce() *felt.Felt {
+	return t.txn.Nonce
+}
+
+// Returns the L2 gas price (in FRI) the last fee estimation was made with, or nil if the
+// fee was never estimated
+func (t *AttestTransaction) GasPrice() *felt.Felt {
+	return t.gasPrice
+}
+
+// Returns the highest L2 gas price (in FRI) the transaction accepts to pay since the last fee
+// estimation, or nil if the fee was never estimated
+func (t *AttestTransaction) MaxGasPrice() *felt.Felt {
+	return t.maxGasPrice
+}
+
+// Returns the unit the fee of the last fee estimation was given in, or empty if the fee was
+// never estimated
+func (t *AttestTransaction) FeeUnit() rpc.FeePaymentUnit {
+	return t.feeUnit
+}
+
+// Returns the token the fee is paid with when given in the unit
+func FeeToken(unit rpc.FeePaymentUnit) string {
+	switch unit {
+	case rpc.UnitStrk:
+		return metrics.FeeTokenSTRK
+	case rpc.UnitWei:
+		return metrics.FeeTokenETH
+	default:
+		return string(unit)
+	}
+}
+
+// Returns the size (in bytes) of the transaction as serialized when sent to the node, or 0 if
+// it can't be serialized
+func (t *AttestTransaction) Size() int {
+	data, err := json.Marshal(t.txn)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
 // I want to name this built or smth like that
 func (t *AttestTransaction) Valid() bool {
 	return t.valid
@@ -105,6 +212,14 @@ type AttestTracker struct {
 	Transaction AttestTransaction
 	Hash        felt.Felt
 	Status      AttestStatus
+	// When the dispatcher first got notified about the target block
+	DetectedAt time.Time
+	// When the attest transaction got accepted by the node
+	SubmittedAt time.Time
+	// Reason of the latest failure while attesting, if any
+	failure string
+	// End of the attestation window, used to tell whether the attestation landed late
+	windowEnd types.BlockNumber
 }
 
 func NewAttestTracker() AttestTracker {
@@ -120,6 +235,22 @@ func (a *AttestTracker) UpdateStatus(
 ) {
 	status := TrackAttest(signer, logger, &a.Hash)
 	a.setStatus(status)
+	if status == Failed {
+		a.failure = ReasonTxnFailed
+	}
+}
+
+// Returns why the attestation didn't succeed. If no failure was seen, it tells apart a
+// transaction that was never submitted from one that didn't get confirmed in time
+func (a *AttestTracker) FailureReason() string {
+	switch {
+	case a.failure != "":
+		return a.failure
+	case a.SubmittedAt.IsZero():
+		return ReasonNotSubmitted
+	default:
+		return ReasonNotConfirmed
+	}
 }
 
 func (a *AttestTracker) setStatus(status AttestStatus) {
@@ -135,6 +266,15 @@ func (a *AttestTracker) setStatus(status AttestStatus) {
 	}
 }
 
+// Sets the detection time the first time it is called and reports whether it did
+func (a *AttestTracker) markDetected() bool {
+	if !a.DetectedAt.IsZero() {
+		return false
+	}
+	a.DetectedAt = time.Now()
+	return true
+}
+
 type EventDispatcher[S signerP.Signer] struct {
 	// Current epoch attest-related fields
 	CurrentAttest AttestTracker
@@ -142,6 +282,9 @@ type EventDispatcher[S signerP.Signer] struct {
 	DoAttest      chan types.DoAttest
 	PrepareAttest chan types.PrepareAttest
 	EndOfWindow   chan struct{}
+	// Account receiving the staking rewards, whose balance is checked along the signer one.
+	// Nil when it isn't tracked
+	RewardsAddress *types.Address
 }
 
 func NewEventDispatcher[S signerP.Signer]() EventDispatcher[S] {
@@ -168,6 +311,9 @@ func (d *EventDispatcher[S]) Dispatch(
 			if !ok {
 				return
 			}
+			if d.CurrentAttest.markDetected() {
+				tracer.RecordAttestationDetected()
+			}
 			if d.CurrentAttest.Status != Iddle {
 				logger.Error("receiveing prepare attest info while doing attest")
 			}
@@ -177,9 +323,12 @@ func (d *EventDispatcher[S]) Dispatch(
 
 			targetBlockHash = attest.BlockHash
 			logger.Debugf("preparing attest transaction for blockhash: %s", targetBlockHash.String())
+			buildStart := time.Now()
 			err := d.CurrentAttest.Transaction.Build(signer, &targetBlockHash)
+			tracer.RecordAttestationBuilt(buildStart, err)
 			if err != nil {
 				logger.Errorf("failed to build attest transaction: %s", err.Error())
+				d.CurrentAttest.failure = ReasonBuildFailed
 				continue
 			}
 			logger.Debug("built attest transaction successfully")
@@ -188,18 +337,26 @@ func (d *EventDispatcher[S]) Dispatch(
 			if !ok {
 				return
 			}
+			if d.CurrentAttest.markDetected() {
+				tracer.RecordAttestationDetected()
+			}
+			d.CurrentAttest.windowEnd = attest.WindowEnd
 
 			// if the attest event is already being tracked by the tool
 			if d.CurrentAttest.Status != Iddle && d.CurrentAttest.Status != Failed {
 				// If  status is still not successful, check for it
 				if d.CurrentAttest.Status != Successful {
-					d.CurrentAttest.UpdateStatus(signer, logger)
+					d.updateAttestStatus(signer, logger, tracer)
 				}
 				// If status is status is already successful or ongoing, do nothing.
 				if d.CurrentAttest.Status == Successful || d.CurrentAttest.Status == Ongoing {
 					continue
 				}
 			}
+			// A previous attempt in this window failed, so this one is a retry
+			if d.CurrentAttest.Status == Failed {
+				tracer.RecordAttestationRetry()
+			}
 			d.CurrentAttest.setStatus(Ongoing)
 
 			// Case when the validator is initiated mid window and didn't have time to prepare
@@ -207,9 +364,12 @@ func (d *EventDispatcher[S]) Dispatch(
 			if !d.CurrentAttest.Transaction.Valid() {
 				targetBlockHash = attest.BlockHash
 				logger.Debugf("building attest transaction (in `do` stage) for blockhash: %s", &targetBlockHash)
+				buildStart := time.Now()
 				err := d.CurrentAttest.Transaction.Build(signer, &targetBlockHash)
+				tracer.RecordAttestationBuilt(buildStart, err)
 				if err != nil {
 					logger.Errorf("failed to build attest transaction: %s", err.Error())
+					d.CurrentAttest.failure = ReasonBuildFailed
 					continue
 				}
 				logger.Debug("built attest transaction successfully")
@@ -220,13 +380,29 @@ func (d *EventDispatcher[S]) Dispatch(
 				err := d.CurrentAttest.Transaction.UpdateNonce(signer)
 				if err != nil {
 					logger.Errorf("failed to update transaction nonce: %s", err.Error())
+					d.CurrentAttest.failure = ReasonNonceUpdateFailed
 					continue
 				}
 			}
 
 			logger.Infow("Invoking attest", "block hash", targetBlockHash.String())
+			invokeStart := time.Now()
 			resp, err := d.CurrentAttest.Transaction.Invoke(signer)
+			if gasPrice := d.CurrentAttest.Transaction.GasPrice(); gasPrice != nil {
+				price := types.NewBalance(gasPrice, &felt.Zero)
+				priceF, _ := price.BigFloat().Float64()
+				tracer.UpdateGasPrice(priceF)
+			}
+			if maxGasPrice := d.CurrentAttest.Transaction.MaxGasPrice(); maxGasPrice != nil {
+				maxPrice := types.NewBalance(maxGasPrice, &felt.Zero)
+				maxPriceF, _ := maxPrice.BigFloat().Float64()
+				tracer.UpdateMaxFeeCap(maxPriceF)
+			}
+			if unit := d.CurrentAttest.Transaction.FeeUnit(); unit != "" {
+				tracer.UpdateFeeToken(FeeToken(unit))
+			}
 			if err != nil {
+				tracer.RecordAttestationInvoked(invokeStart, "", err)
 				if strings.Contains(err.Error(), "Attestation is done for this epoch") {
 					logger.Infow(
 						"Attestation is already done for this epoch",
@@ -242,39 +418,127 @@ func (d *EventDispatcher[S]) Dispatch(
 					"error", err,
 				)
 				d.CurrentAttest.setStatus(Failed)
+				d.CurrentAttest.failure = InvokeFailureReason(err)
+				if d.CurrentAttest.failure == ReasonInsufficientFunds {
+					tracer.RecordAttestationSkippedInsufficientFunds()
+				}
 
 				continue
 			}
 			logger.Debugw("Attest transaction sent", "hash", resp.Hash)
+			tracer.RecordAttestationInvoked(invokeStart, resp.Hash.String(), nil)
 			d.CurrentAttest.Hash = *resp.Hash
+			d.CurrentAttest.SubmittedAt = time.Now()
 			// Record attestation submission in metrics
 			tracer.RecordAttestationSubmitted()
+			tracer.RecordAttestationSubmissionLatency(
+				d.CurrentAttest.SubmittedAt.Sub(d.CurrentAttest.DetectedAt),
+			)
+			if nonce := d.CurrentAttest.Transaction.Nonce(); nonce != nil {
+				tracer.UpdateSignerNonce(nonce.Uint64())
+			}
+			if size := d.CurrentAttest.Transaction.Size(); size > 0 {
+				tracer.RecordAttestationTxSize(size)
+			}
 
 		case <-d.EndOfWindow:
 			logger.Info("End of window reached")
 			if d.CurrentAttest.Status != Successful {
-				d.CurrentAttest.UpdateStatus(signer, logger)
+				d.updateAttestStatus(signer, logger, tracer)
 			}
 			if d.CurrentAttest.Status == Successful {
 				logger.Infow(
 					"Successfully attested to target block",
 					"target block hash", targetBlockHash.String(),
 				)
-				tracer.RecordAttestationConfirmed()
+				txHash := ""
+				if !d.CurrentAttest.Hash.IsZero() {
+					txHash = d.CurrentAttest.Hash.String()
+				}
+				tracer.RecordAttestationConfirmed(txHash)
 			} else {
+				reason := d.CurrentAttest.FailureReason()
 				logger.Warnw(
 					"Failed to attest to target block",
 					"target block hash", targetBlockHash.String(),
 					"latest attest status", d.CurrentAttest.Status,
+					"reason", reason,
 				)
-				tracer.RecordAttestationFailure()
+				// Every window without a confirmed attestation is missed, and failed as well
+				// when attesting was attempted
+				if reason != ReasonNotSubmitted {
+					tracer.RecordAttestationFailure(reason)
+				}
+				tracer.RecordAttestationMissed()
 			}
 			// clean slate for the next window
 			d.CurrentAttest = NewAttestTracker()
 			// check the account balance
 			go CheckBalance(signer, balanceThreshold, logger, tracer)
+			if d.RewardsAddress != nil {
+				go CheckRewardsBalance(signer, d.RewardsAddress, logger, tracer)
+			}
+		}
+	}
+}
+
+// Queries the current attest status and records the confirmation latency and fee the moment
+// a submitted attest transaction is seen as successful
+func (d *EventDispatcher[S]) updateAttestStatus(
+	signer S, logger *junoUtils.ZapLogger, tracer metrics.Tracer,
+) {
+	d.CurrentAttest.UpdateStatus(signer, logger)
+	if d.CurrentAttest.Status == Successful && !d.CurrentAttest.SubmittedAt.IsZero() {
+		tracer.RecordAttestationConfirmationLatency(time.Since(d.CurrentAttest.SubmittedAt))
+		if !d.CurrentAttest.DetectedAt.IsZero() {
+			tracer.RecordAttestationCycle(time.Since(d.CurrentAttest.DetectedAt))
 		}
+		RecordAttestReceipt(signer, logger, &d.CurrentAttest.Hash, d.CurrentAttest.windowEnd, tracer)
+	}
+}
+
+// Fetches the receipt of a confirmed attest transaction and records the fee it paid, when
+// paid in STRK. If it was included past the window end, it is also recorded as late. A zero window end is ignored
+func RecordAttestReceipt[S signerP.Signer](
+	signer S,
+	logger *junoUtils.ZapLogger,
+	txHash *felt.Felt,
+	windowEnd types.BlockNumber,
+	tracer metrics.Tracer,
+) {
+	receipt, err := signer.GetTransactionReceipt(txHash)
+	if err != nil {
+		logger.Warnw(
+			"Unable to get attest transaction receipt",
+			"transaction hash", txHash,
+			"error", err,
+		)
+		return
+	}
+	if windowEnd != 0 && types.BlockNumber(receipt.BlockNumber) >= windowEnd {
+		logger.Warnw(
+			"Attest transaction landed after the attestation window",
+			"transaction hash", txHash,
+			"block number", receipt.BlockNumber,
+			"window end", windowEnd,
+		)
+		tracer.RecordAttestationLate()
+	}
+	if receipt.ActualFee.Amount == nil {
+		return
+	}
+	fee := types.NewBalance(receipt.ActualFee.Amount, &felt.Zero)
+	logger.Debugw(
+		"Attest transaction fee",
+		"transaction hash", txHash,
+		"amount", fee.Text(10),
+		"unit", receipt.ActualFee.Unit,
+	)
+	// The fee metric is in STRK, a fee paid in ETH (WEI) can't be added to it
+	if receipt.ActualFee.Unit != rpc.UnitStrk {
+		return
 	}
+	tracer.RecordAttestationFee(fee.Strk())
 }
 
 func TrackAttest[S signerP.Signer](
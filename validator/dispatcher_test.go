@@ -1,12 +1,17 @@
 package validator_test
 
 import (
+	"context"
+	"net"
 	"testing"
+	"time"
 
 	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/NethermindEth/starknet-staking-v2/mocks"
 	"github.com/NethermindEth/starknet-staking-v2/validator"
+	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
+	"github.com/NethermindEth/starknet-staking-v2/validator/types"
 	"github.com/NethermindEth/starknet.go/rpc"
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/require"
@@ -558,3 +563,190 @@ func TestTrackAttest(t *testing.T) {
 		require.Equal(t, validator.Successful, txStatus)
 	})
 }
+
+func TestAttestTrackerFailureReason(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockSigner := mocks.NewMockSigner(mockCtrl)
+	logger := utils.NewNopZapLogger()
+
+	t.Run("attestation never submitted", func(t *testing.T) {
+		tracker := validator.NewAttestTracker()
+		require.Equal(t, validator.ReasonNotSubmitted, tracker.FailureReason())
+	})
+
+	t.Run("attestation submitted but not confirmed", func(t *testing.T) {
+		tracker := validator.NewAttestTracker()
+		tracker.SubmittedAt = time.Now()
+		require.Equal(t, validator.ReasonNotConfirmed, tracker.FailureReason())
+	})
+
+	t.Run("attestation transaction failed", func(t *testing.T) {
+		tracker := validator.NewAttestTracker()
+		tracker.Hash = *new(felt.Felt).SetUint64(1)
+		tracker.SubmittedAt = time.Now()
+
+		mockSigner.EXPECT().
+			GetTransactionStatus(&tracker.Hash).
+			Return(&rpc.TxnStatusResult{FinalityStatus: rpc.TxnStatus_Rejected}, nil)
+
+		tracker.UpdateStatus(mockSigner, logger)
+
+		require.Equal(t, validator.Failed, tracker.Status)
+		require.Equal(t, validator.ReasonTxnFailed, tracker.FailureReason())
+	})
+}
+
+func TestAttestTransactionMaxGasPrice(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockSigner := mocks.NewMockSigner(mockCtrl)
+	blockHash := new(types.BlockHash)
+	mockSigner.EXPECT().BuildAttestTransaction(blockHash).Return(rpc.BroadcastInvokeTxnV3{}, nil)
+	mockSigner.EXPECT().SignTransaction(gomock.Any()).Return(nil, nil).Times(2)
+	mockSigner.EXPECT().EstimateFee(gomock.Any()).Return(rpc.FeeEstimation{
+		L1GasConsumed:     new(felt.Felt),
+		L1GasPrice:        new(felt.Felt),
+		L2GasConsumed:     new(felt.Felt).SetUint64(100),
+		L2GasPrice:        new(felt.Felt).SetUint64(1000),
+		L1DataGasConsumed: new(felt.Felt),
+		L1DataGasPrice:    new(felt.Felt),
+		FeeUnit:           rpc.UnitStrk,
+	}, nil)
+	mockSigner.EXPECT().InvokeTransaction(gomock.Any()).Return(&rpc.AddInvokeTransactionResponse{}, nil)
+
+	var transaction validator.AttestTransaction
+	require.NoError(t, transaction.Build(mockSigner, blockHash))
+	// The fee was never estimated
+	require.Nil(t, transaction.MaxGasPrice())
+
+	_, err := transaction.Invoke(mockSigner)
+	require.NoError(t, err)
+	require.Equal(t, new(felt.Felt).SetUint64(1000), transaction.GasPrice())
+	require.Equal(t, new(felt.Felt).SetUint64(1500), transaction.MaxGasPrice())
+}
+
+func TestFeeToken(t *testing.T) {
+	require.Equal(t, metrics.FeeTokenSTRK, validator.FeeToken(rpc.UnitStrk))
+	require.Equal(t, metrics.FeeTokenETH, validator.FeeToken(rpc.UnitWei))
+}
+
+func TestInvokeFailureReason(t *testing.T) {
+	internalErr := func(message string) error {
+		return &rpc.RPCError{
+			Code:    rpc.InternalError,
+			Message: "The error is not a valid RPC error",
+			Data:    rpc.StringErrData(message),
+		}
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		reason string
+	}{
+		{"node rejects the transaction", rpc.ErrInvalidTransactionNonce, validator.ReasonRPCRejected},
+		{"signer can't pay the fee", rpc.ErrInsufficientAccountBalance, validator.ReasonInsufficientFunds},
+		{"node is unreachable", internalErr("dial tcp: connection refused"), validator.ReasonNetworkError},
+		{"node doesn't answer in time", internalErr("context deadline exceeded"), validator.ReasonTimeout},
+		{"context deadline exceeded", errors.Wrap(context.DeadlineExceeded, "invoke"), validator.ReasonTimeout},
+		{"network error", &net.OpError{Op: "dial", Err: errors.New("refused")}, validator.ReasonNetworkError},
+		{"any other error", errors.New("some error"), validator.ReasonInvokeFailed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.reason, validator.InvokeFailureReason(test.err))
+		})
+	}
+}
+
+type receiptTracer struct {
+	metrics.NoOpMetrics
+	fees []float64
+	late int
+}
+
+func (r *receiptTracer) RecordAttestationFee(amount float64) {
+	r.fees = append(r.fees, amount)
+}
+
+func (r *receiptTracer) RecordAttestationLate() {
+	r.late++
+}
+
+func TestRecordAttestReceipt(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockSigner := mocks.NewMockSigner(mockCtrl)
+	logger := utils.NewNopZapLogger()
+
+	t.Run("nothing is recorded if the receipt cannot be fetched", func(t *testing.T) {
+		txHash := new(felt.Felt).SetUint64(1)
+		tracer := &receiptTracer{}
+
+		mockSigner.EXPECT().
+			GetTransactionReceipt(txHash).
+			Return(nil, errors.New("some internal error"))
+
+		validator.RecordAttestReceipt(mockSigner, logger, txHash, 0, tracer)
+
+		require.Empty(t, tracer.fees)
+		require.Zero(t, tracer.late)
+	})
+
+	t.Run("fee is recorded in STRK", func(t *testing.T) {
+		txHash := new(felt.Felt).SetUint64(1)
+		tracer := &receiptTracer{}
+
+		// 0.5 STRK
+		amount := new(felt.Felt).SetUint64(5e17)
+		mockSigner.EXPECT().
+			GetTransactionReceipt(txHash).
+			Return(&rpc.TransactionReceiptWithBlockInfo{
+				TransactionReceipt: rpc.TransactionReceipt{
+					ActualFee: rpc.FeePayment{Amount: amount, Unit: rpc.UnitStrk},
+				},
+			}, nil)
+
+		validator.RecordAttestReceipt(mockSigner, logger, txHash, 0, tracer)
+
+		require.Equal(t, []float64{0.5}, tracer.fees)
+	})
+
+	t.Run("fee paid in WEI is not recorded", func(t *testing.T) {
+		txHash := new(felt.Felt).SetUint64(1)
+		tracer := &receiptTracer{}
+
+		mockSigner.EXPECT().
+			GetTransactionReceipt(txHash).
+			Return(&rpc.TransactionReceiptWithBlockInfo{
+				TransactionReceipt: rpc.TransactionReceipt{
+					ActualFee: rpc.FeePayment{
+						Amount: new(felt.Felt).SetUint64(5e17), Unit: rpc.UnitWei,
+					},
+				},
+			}, nil)
+
+		validator.RecordAttestReceipt(mockSigner, logger, txHash, 0, tracer)
+
+		require.Empty(t, tracer.fees)
+	})
+
+	t.Run("attestation is late when included past the window", func(t *testing.T) {
+		for blockNumber, late := range map[uint]int{119: 0, 120: 1, 125: 1} {
+			txHash := new(felt.Felt).SetUint64(1)
+			tracer := &receiptTracer{}
+
+			mockSigner.EXPECT().
+				GetTransactionReceipt(txHash).
+				Return(&rpc.TransactionReceiptWithBlockInfo{BlockNumber: blockNumber}, nil)
+
+			validator.RecordAttestReceipt(mockSigner, logger, txHash, 120, tracer)
+
+			require.Equal(t, late, tracer.late, "block number %d", blockNumber)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package validator
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
+)
+
+// Components taking longer than this to answer a health check are reported as degraded
+const slowHealthCheck = 2 * time.Second
+
+// Reasons reported next to a component health. The underlying errors are only logged since
+// they may contain the node or signer url, and with it an API key
+const (
+	healthReasonUnreachable    = "unreachable"
+	healthReasonInvalidURL     = "invalid_url"
+	healthReasonSlow           = "slow_response"
+	healthReasonInternalSigner = "internal_signer"
+)
+
+// Registers the node and signer health into the detailed health report of the metrics server
+func (v *Validator) RegisterHealthCheckers(m *metrics.Metrics) {
+	m.RegisterHealthChecker("rpc", metrics.HealthCheckerFunc(v.RPCHealth))
+	m.RegisterHealthChecker("signer", metrics.HealthCheckerFunc(v.SignerHealth))
+}
+
+// Reports whether the node can be reached through the RPC provider
+func (v *Validator) RPCHealth(ctx context.Context) metrics.ComponentHealth {
+	start := time.Now()
+	if _, err := v.provider.BlockNumber(ctx); err != nil {
+		v.logger.Debugf("RPC health check failed: %s", err)
+		return metrics.ComponentHealth{Status: metrics.HealthDown, Message: healthReasonUnreachable}
+	}
+	return timedHealth(time.Since(start))
+}
+
+// Reports whether the external signer can be reached. The internal signer is always healthy
+func (v *Validator) SignerHealth(ctx context.Context) metrics.ComponentHealth {
+	if v.signerURL == "" {
+		return metrics.ComponentHealth{Status: metrics.HealthOK, Message: healthReasonInternalSigner}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.signerURL, http.NoBody)
+	if err != nil {
+		v.logger.Debugf("Signer health check failed: %s", err)
+		return metrics.ComponentHealth{Status: metrics.HealthDown, Message: healthReasonInvalidURL}
+	}
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		v.logger.Debugf("Signer health check failed: %s", err)
+		return metrics.ComponentHealth{Status: metrics.HealthDown, Message: healthReasonUnreachable}
+	}
+	resp.Body.Close()
+	// Any response means the signer is reachable, its status code is irrelevant here
+	return timedHealth(time.Since(start))
+}
+
+func timedHealth(elapsed time.Duration) metrics.ComponentHealth {
+	if elapsed > slowHealthCheck {
+		return metrics.ComponentHealth{
+			Status:  metrics.HealthDegraded,
+			Message: healthReasonSlow,
+		}
+	}
+	return metrics.ComponentHealth{Status: metrics.HealthOK}
+}
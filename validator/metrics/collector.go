@@ -0,0 +1,170 @@
+package metrics
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Computes, on every scrape, the metrics derived from the internal state of each network
+// sharing the exporter. Unlike a gauge set when something happens, their value is accurate
+// even when nothing happened between two scrapes
+type derivedCollector struct {
+	sinceLastConfirmed *prometheus.Desc
+
+	mu       sync.Mutex
+	networks []*Metrics
+}
+
+func newDerivedCollector(namespace, subsystem string) *derivedCollector {
+	return &derivedCollector{
+		sinceLastConfirmed: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "seconds_since_last_confirmed_attestation"),
+			"The time (in seconds) elapsed since the last attestation confirmed on the network",
+			[]string{"network"},
+			nil,
+		),
+	}
+}
+
+// Adds the metrics of a network to the ones collected. If the network is already collected,
+// nothing is added and the metrics already collected are returned along with false
+func (c *derivedCollector) add(m *Metrics) (*Metrics, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, existing := range c.networks {
+		if existing.network == m.network {
+			return existing, false
+		}
+	}
+	c.networks = append(c.networks, m)
+	return m, true
+}
+
+func (c *derivedCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.sinceLastConfirmed
+}
+
+// Returns the metrics of every network collected
+func (c *derivedCollector) all() []*Metrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.networks)
+}
+
+func (c *derivedCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c.all() {
+		m.mu.Lock()
+		lastConfirmed := m.lastConfirmedAt
+		m.mu.Unlock()
+
+		// Nothing is exported until the first attestation gets confirmed
+		if lastConfirmed.IsZero() {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.sinceLastConfirmed,
+			prometheus.GaugeValue,
+			m.clock().Sub(lastConfirmed).Seconds(),
+			m.network,
+		)
+	}
+}
+
+// Computes, on every scrape, how long the validator process has been running for each
+// network. Frequent restarts show up as the value repeatedly dropping back to zero
+type uptimeCollector struct {
+	uptime *prometheus.Desc
+	// Networks sharing the exporter, which all share the process start time
+	networks *derivedCollector
+}
+
+func newUptimeCollector(namespace, subsystem string, networks *derivedCollector) *uptimeCollector {
+	return &uptimeCollector{
+		uptime: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, subsystem, "process_uptime_seconds"),
+			"The time (in seconds) elapsed since the validator process started",
+			[]string{"network"},
+			nil,
+		),
+		networks: networks,
+	}
+}
+
+func (c *uptimeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.uptime
+}
+
+func (c *uptimeCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c.networks.all() {
+		ch <- prometheus.MustNewConstMetric(
+			c.uptime,
+			prometheus.GaugeValue,
+			max(m.clock().Sub(m.processStart), time.Duration(0)).Seconds(),
+			m.network,
+		)
+	}
+}
+
+// Registers every collector, stopping at the first one that cannot be registered instead of
+// panicking like `MustRegister`. The error names the collector that conflicted
+func register(registry prometheus.Registerer, collectors ...prometheus.Collector) error {
+	for _, c := range collectors {
+		if err := registry.Register(c); err != nil {
+			return fmt.Errorf("%w %s: %w", ErrRegistration, collectorName(c), err)
+		}
+	}
+	return nil
+}
+
+// Returns the collectors but the disabled ones, named without namespace and subsystem.
+// Naming a metric none of the collectors describes is an error
+func withoutDisabled(
+	namespace, subsystem string, disabled []string, collectors []prometheus.Collector,
+) ([]prometheus.Collector, error) {
+	if len(disabled) == 0 {
+		return collectors, nil
+	}
+
+	names := make(map[string]prometheus.Collector, len(collectors))
+	for _, c := range collectors {
+		names[collectorName(c)] = c
+	}
+	skipped := make(map[prometheus.Collector]bool, len(disabled))
+	for _, name := range disabled {
+		c, ok := names[prometheus.BuildFQName(namespace, subsystem, name)]
+		if !ok {
+			return nil, fmt.Errorf("cannot disable unknown metric %q", name)
+		}
+		skipped[c] = true
+	}
+	return slices.DeleteFunc(slices.Clone(collectors), func(c prometheus.Collector) bool {
+		return skipped[c]
+	}), nil
+}
+
+// Returns the fully-qualified name of the first metric described by the collector
+func collectorName(c prometheus.Collector) string {
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		c.Describe(ch)
+		close(ch)
+	}()
+
+	name := "unknown"
+	for desc := range ch {
+		if name != "unknown" {
+			continue
+		}
+		// A desc only exposes its name through its string representation
+		_, after, found := strings.Cut(desc.String(), `fqName: "`)
+		if found {
+			name, _, _ = strings.Cut(after, `"`)
+		}
+	}
+	return name
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import "time"
+
+// Keys of the fields of every event, logged at debug level and passed to the event hook.
+// A key always holds the same kind of value so log pipelines can parse them reliably:
+// durations are in seconds, timestamps are unix seconds and errors are their message
+const (
+	FieldNetwork        = "network"
+	FieldReady          = "ready"
+	FieldActive         = "active"
+	FieldMismatch       = "mismatch"
+	FieldBlockNumber    = "block_number"
+	FieldBlockTimestamp = "block_timestamp"
+	FieldBlocks         = "blocks"
+	FieldEpochID        = "epoch_id"
+	FieldEpochLength    = "epoch_length"
+	FieldStartingBlock  = "starting_block"
+	FieldAssignedBlock  = "assigned_block"
+	FieldWindow         = "window"
+	FieldAddress        = "address"
+	FieldAddressRole    = "address_role"
+	FieldBalance        = "balance"
+	FieldAmount         = "amount"
+	FieldPrice          = "price"
+	FieldToken          = "token"
+	FieldThreshold      = "threshold"
+	FieldNonce          = "nonce"
+	FieldTxHash         = "tx_hash"
+	FieldBytes          = "bytes"
+	FieldDuration       = "duration_seconds"
+	FieldReason         = "reason"
+	FieldError          = "error"
+	FieldOK             = "ok"
+	FieldMethod         = "method"
+	FieldDepth          = "depth"
+)
+
+// Value of a duration field
+func seconds(d time.Duration) float64 {
+	return d.Seconds()
+}
+
+// Value of an error field, empty when there is no error
+func errorField(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
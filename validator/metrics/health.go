@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"time"
+)
+
+type HealthStatus string
+
+const (
+	HealthOK       HealthStatus = "ok"
+	HealthDegraded HealthStatus = "degraded"
+	HealthDown     HealthStatus = "down"
+)
+
+// How long every registered health checker is given to report back
+const healthCheckTimeout = 5 * time.Second
+
+// How long a health report is reused, so frequent probes don't turn into as many requests to
+// the node and the signer
+const healthCacheTTL = 5 * time.Second
+
+// Name of the built-in component reporting the metrics server health
+const metricsComponent = "metrics"
+
+// ComponentHealth is the health reported by a single validator component. The message is a
+// short reason (e.g. `unreachable`), never an error text which may hold an url or an API key
+type ComponentHealth struct {
+	Status  HealthStatus `json:"status"`
+	Message string       `json:"message,omitempty"`
+}
+
+// HealthChecker reports the health of a single validator component, e.g. the node
+// connection or the signer
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) ComponentHealth
+}
+
+// HealthCheckerFunc allows a plain function to be used as a health checker
+type HealthCheckerFunc func(ctx context.Context) ComponentHealth
+
+func (f HealthCheckerFunc) CheckHealth(ctx context.Context) ComponentHealth {
+	return f(ctx)
+}
+
+// Health aggregates the health of every component. The overall status is the worst
+// among them
+type Health struct {
+	Status     HealthStatus               `json:"status"`
+	Components map[string]ComponentHealth `json:"components"`
+}
+
+// RegisterHealthChecker adds a component to the detailed health report under the given
+// name. Registering a name twice replaces the previous checker
+func (m *Metrics) RegisterHealthChecker(name string, checker HealthChecker) {
+	m.healthMu.Lock()
+	defer m.healthMu.Unlock()
+	m.healthCheckers[name] = checker
+	m.healthAt = time.Time{}
+}
+
+// Health runs every registered health checker and aggregates their result, together with
+// the metrics server uptime. The report is reused for a few seconds
+func (m *Metrics) Health(ctx context.Context) Health {
+	now := m.clock()
+	m.healthMu.RLock()
+	health, healthAt := m.health, m.healthAt
+	m.healthMu.RUnlock()
+	if !healthAt.IsZero() && now.Sub(healthAt) < healthCacheTTL {
+		health.Components = maps.Clone(health.Components)
+		return health
+	}
+
+	health = m.checkHealth(ctx)
+	m.healthMu.Lock()
+	m.health = health
+	m.health.Components = maps.Clone(health.Components)
+	m.healthAt = now
+	m.healthMu.Unlock()
+	return health
+}
+
+// Runs every registered health checker and aggregates their result
+func (m *Metrics) checkHealth(ctx context.Context) Health {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	m.healthMu.RLock()
+	checkers := make(map[string]HealthChecker, len(m.healthCheckers))
+	for name, checker := range m.healthCheckers {
+		checkers[name] = checker
+	}
+	startedAt := m.startedAt
+	m.healthMu.RUnlock()
+
+	health := Health{
+		Status:     HealthOK,
+		Components: make(map[string]ComponentHealth, len(checkers)+1),
+	}
+	add := func(name string, component ComponentHealth) {
+		health.Components[name] = component
+		if severity(component.Status) > severity(health.Status) {
+			health.Status = component.Status
+		}
+	}
+
+	if startedAt.IsZero() {
+		add(metricsComponent, ComponentHealth{Status: HealthDown, Message: "not started"})
+	} else {
+		uptime := m.clock().Sub(startedAt).Truncate(time.Second)
+		add(metricsComponent, ComponentHealth{
+			Status:  HealthOK,
+			Message: fmt.Sprintf("up %s", uptime),
+		})
+	}
+	for name, checker := range checkers {
+		add(name, checker.CheckHealth(ctx))
+	}
+	return health
+}
+
+// Serves a plain OK
+func (m *Metrics) serveHealth(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write([]byte("OK"))
+	if err != nil {
+		m.logger.Errorf("Failed to write health check response: %v", err)
+	}
+}
+
+// Serves the health of every component as JSON, answering with 503 if any of them is down
+func (m *Metrics) serveHealthReport(w http.ResponseWriter, r *http.Request) {
+	health := m.Health(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	if health.Status == HealthDown {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(health); err != nil {
+		m.logger.Errorf("Failed to write health check response: %v", err)
+	}
+}
+
+func severity(status HealthStatus) int {
+	switch status {
+	case HealthOK:
+		return 0
+	case HealthDegraded:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,11 @@
+package metrics
+
+// Logger is the subset of a structured logger used by the metrics package. Juno's
+// `*utils.ZapLogger` satisfies it, but any logger providing these methods can be used
+type Logger interface {
+	Debugf(msg string, args ...any)
+	Debugw(msg string, keysAndValues ...any)
+	Infof(msg string, args ...any)
+	Warnw(msg string, keysAndValues ...any)
+	Errorf(msg string, args ...any)
+}
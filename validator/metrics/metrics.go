@@ -2,220 +2,2234 @@ package metrics
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/starknet-staking-v2/validator/notify"
 	"github.com/NethermindEth/starknet-staking-v2/validator/types"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
 )
 
 var _ Tracer = (*Metrics)(nil)
 
-// Metrics represents the metrics server for the validator
-type Metrics struct {
+// Values of the `result` label of the attestation result metric
+const (
+	ResultSubmitted = "submitted"
+	ResultConfirmed = "confirmed"
+	ResultFailed    = "failed"
+	ResultMissed    = "missed"
+)
+
+// Values of the `address_role` label of the signer balance metrics. Staking separates the
+// operational address, which signs the attestations, from the one receiving the rewards
+const (
+	AddressRoleOperational = "operational"
+	AddressRoleRewards     = "rewards"
+)
+
+// Values of the `token` label of the fee token metric
+const (
+	FeeTokenSTRK = "STRK"
+	FeeTokenETH  = "ETH"
+)
+
+const (
+	DefaultNamespace       = "validator"
+	DefaultSubsystem       = "attestation"
+	DefaultShutdownTimeout = 5 * time.Second
+	// Server timeouts, so slow clients can't hold connections open forever
+	DefaultReadHeaderTimeout = 5 * time.Second
+	DefaultReadTimeout       = 10 * time.Second
+	DefaultWriteTimeout      = 30 * time.Second
+	// Prefix of the server address to listen on a unix domain socket instead of TCP,
+	// e.g. `unix:/run/validator/metrics.sock`
+	UnixSocketPrefix = "unix:"
+
+	notifyTimeout = 10 * time.Second
+
+	// Number of most recent attestation outcomes the success ratio is computed over
+	SuccessRatioWindow = 100
+	// Default number of most recent confirmation latencies the median is computed over
+	DefaultMedianConfirmationWindow = 20
+)
+
+var labelNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Returned when starting metrics whose endpoints are served through an external mux
+var ErrExternalMux = errors.New("metrics are served through an external mux and have no server to start")
+
+// Returned when starting a metrics server which is already running
+var ErrAlreadyRunning = errors.New("metrics server is already running")
+
+// Returned when a metric cannot be registered, e.g. because it conflicts with another one.
+// The validator can keep attesting without its metrics when this happens
+var ErrRegistration = errors.New("cannot register metric")
+
+// TLSFiles holds the certificate and key files used to serve the metrics over TLS.
+// When both are empty the metrics are served in plaintext
+type TLSFiles struct {
+	CertFile string
+	KeyFile  string
+}
+
+func (t *TLSFiles) Enabled() bool {
+	return t.CertFile != "" || t.KeyFile != ""
+}
+
+func (t *TLSFiles) Check() error {
+	if t.CertFile != "" && t.KeyFile == "" {
+		return errors.New("metrics tls certificate file is set but the key file is missing")
+	}
+	if t.CertFile == "" && t.KeyFile != "" {
+		return errors.New("metrics tls key file is set but the certificate file is missing")
+	}
+	return nil
+}
+
+// BasicAuth holds the credentials required to access the metrics endpoint.
+// When both are empty the endpoint is left open
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+func (a *BasicAuth) Enabled() bool {
+	return a.Username != "" || a.Password != ""
+}
+
+func (a *BasicAuth) Check() error {
+	if a.Enabled() && (a.Username == "" || a.Password == "") {
+		return errors.New("metrics basic auth requires both a username and a password")
+	}
+	return nil
+}
+
+// Wraps the handler so it is only served to requests carrying the right credentials
+func (a *BasicAuth) Wrap(handler http.Handler) http.Handler {
+	if !a.Enabled() {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		validUser := subtle.ConstantTimeCompare([]byte(username), []byte(a.Username)) == 1
+		validPass := subtle.ConstantTimeCompare([]byte(password), []byte(a.Password)) == 1
+		if !ok || !validUser || !validPass {
+			w.Header().Set("WWW-Authenticate", `Basic realm="metrics", charset="UTF-8"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// Holds what is shared by the metrics of every network: the server, the registry and
+// the collectors
+type exporter struct {
 	server                          *http.Server
-	logger                          *utils.ZapLogger
-	network                         string
+	mux                             *http.ServeMux
+	runMu                           sync.Mutex
+	running                         bool
+	stopped                         bool
+	tls                             TLSFiles
+	logger                          Logger
+	namespace                       string
 	registry                        *prometheus.Registry
+	collectors                      []prometheus.Collector
+	buildInfo                       *prometheus.GaugeVec
+	dryRunMode                      *prometheus.GaugeVec
+	rpcEndpointInfo                 *prometheus.GaugeVec
+	derived                         *derivedCollector
+	uptime                          *uptimeCollector
+	processStart                    time.Time
 	latestBlockNumber               *prometheus.GaugeVec
+	headBlockAge                    *prometheus.GaugeVec
+	blockLag                        *prometheus.GaugeVec
+	reorgDetectedCount              *prometheus.CounterVec
+	reorgDepth                      *prometheus.GaugeVec
 	currentEpochID                  *prometheus.GaugeVec
 	currentEpochLength              *prometheus.GaugeVec
 	currentEpochStartingBlockNumber *prometheus.GaugeVec
 	currentEpochAssignedBlockNumber *prometheus.GaugeVec
+	epochInfo                       *prometheus.GaugeVec
+	blocksSeenInEpoch               *prometheus.GaugeVec
+	epochTransitionCount            *prometheus.CounterVec
+	epochLengthChangeCount          *prometheus.CounterVec
+	epochsAttestedCount             *prometheus.CounterVec
+	blocksAssignedCount             *prometheus.CounterVec
+	blocksAttestedCount             *prometheus.CounterVec
+	epochFetchDuration              *prometheus.HistogramVec
+	epochFetchFailureCount          *prometheus.CounterVec
+	blockProcessingDuration         *prometheus.HistogramVec
+	attestationWindow               *prometheus.GaugeVec
+	attestationTargetOffset         *prometheus.GaugeVec
+	blocksUntilWindowClose          *prometheus.GaugeVec
+	secondsUntilNextAttestation     *prometheus.GaugeVec
+	assignmentLeadTime              *prometheus.GaugeVec
 	lastAttestationTimestamp        *prometheus.GaugeVec
+	lastConfirmedTimestamp          *prometheus.GaugeVec
 	attestationSubmittedCount       *prometheus.CounterVec
 	attestationFailureCount         *prometheus.CounterVec
 	attestationConfirmedCount       *prometheus.CounterVec
+	attestationMissedCount          *prometheus.CounterVec
+	attestationLateCount            *prometheus.CounterVec
+	consecutiveMissed               *prometheus.GaugeVec
+	pendingAttestations             *prometheus.GaugeVec
+	attestationRetryCount           *prometheus.CounterVec
+	attestationSkippedNoFunds       *prometheus.CounterVec
+	attestationResult               *prometheus.CounterVec
+	attestationSuccessRatio         *prometheus.GaugeVec
+	attestationSubmissionLatency    *prometheus.HistogramVec
+	attestationConfirmationLatency  *prometheus.HistogramVec
+	attestationConfirmationMedian   *prometheus.GaugeVec
+	attestationCycleDuration        *prometheus.HistogramVec
+	attestationFeeSpent             *prometheus.CounterVec
+	attestationTxSize               *prometheus.HistogramVec
+	attestationsPerEpoch            *prometheus.HistogramVec
 	signerBalance                   *prometheus.GaugeVec
+	signerBalanceUSD                *prometheus.GaugeVec
+	signerBalanceKnown              *prometheus.GaugeVec
+	signerBalanceDelta              *prometheus.GaugeVec
+	validatorStake                  *prometheus.GaugeVec
+	validatorActive                 *prometheus.GaugeVec
+	chainIDMismatch                 *prometheus.GaugeVec
 	signerBalanceBelowThreshold     *prometheus.GaugeVec
+	signerBelowThresholdSinceEpoch  *prometheus.GaugeVec
+	signerAccountReady              *prometheus.GaugeVec
+	signerBalanceThreshold          *prometheus.GaugeVec
+	signerNonce                     *prometheus.GaugeVec
+	signerSignCount                 *prometheus.CounterVec
+	signerSignFailureCount          *prometheus.CounterVec
+	feeEstimationFailureCount       *prometheus.CounterVec
+	currentGasPrice                 *prometheus.GaugeVec
+	maxFeeCap                       *prometheus.GaugeVec
+	feeTokenInfo                    *prometheus.GaugeVec
+	rpcRequests                     *prometheus.CounterVec
+	rpcReconnectCount               *prometheus.CounterVec
+	lastError                       *prometheus.GaugeVec
+	// Build info of the running validator and whether it runs in dry-run mode, set for each
+	// network
+	version string
+	commit  string
+	dryRun  bool
+	// Number of most recent confirmation latencies the median is computed over
+	medianWindow int
+	// Called on every gather to refresh the metrics derived from the current time
+	refreshMu  sync.Mutex
+	refreshers []func()
+
+	// Components reported by the detailed health check, and the last report with the time
+	// it was made at
+	healthMu       sync.RWMutex
+	healthCheckers map[string]HealthChecker
+	startedAt      time.Time
+	health         Health
+	healthAt       time.Time
+}
+
+// Metrics represents the metrics server for the validator. Every series is labeled with
+// its network, see `ForNetwork` to track several networks through the same server
+type Metrics struct {
+	*exporter
+	// Value of the network label, which defaults to the chain id
+	network string
+	chainID string
+
+	// When set, it is called on every recorded event with the event name and its fields.
+	// It allows forwarding the validator events to an external system
+	EventHook func(event string, fields map[string]any)
+	// When set, it is notified when the signer balance drops below threshold or an
+	// attestation fails
+	Notifier notify.Notifier
+	// Pushgateway url used by `StartPush`
+	PushURL string
+	// Prometheus remote write url used by `StartRemoteWrite`, together with its credentials
+	RemoteWriteURL  string
+	RemoteWriteAuth BasicAuth
+	// When set, the signer balance is also reported in USD
+	PriceProvider PriceProvider
+	// Serves the `/debug/reset` endpoint. Strictly a testing aid, disabled by default
+	EnableDebugEndpoints bool
+
+	ready atomic.Bool
+	// Source of the current time of the timestamp and age metrics, replaced by tests
+	clock func() time.Time
+	// Internal state used to derive some of the metrics
+	mu           sync.Mutex
+	epochSeen    bool
+	lastEpochID  uint64
+	lastEpochLen uint64
+	// Last epoch in which an attestation got confirmed
+	attestedSeen        bool
+	lastAttestedEpochID uint64
+	// Block range of the current epoch and the distinct blocks processed within it
+	epochStart uint64
+	epochEnd   uint64
+	blocksSeen map[uint64]struct{}
+	// Timestamp of the latest block
+	headTimestamp time.Time
+	// Latest block and assigned block of the current epoch, together with the average time
+	// between blocks, to estimate when the next attestation happens
+	headBlock     uint64
+	assignedBlock uint64
+	blockTime     time.Duration
+	// When the last attestation got confirmed
+	lastConfirmedAt time.Time
+	// Number of attestations confirmed in the current epoch
+	confirmedInEpoch int
+	// Number of attestations missed in a row
+	missedStreak int
+	// Whether a failure was recorded for the window not yet reported as missed
+	windowFailed bool
+	// Epoch in which the balance of each signer address dropped below threshold
+	belowThresholdSince map[string]uint64
+	// Last balance of each signer address
+	lastBalance map[string]float64
+	// Number of submitted attestation transactions not yet confirmed nor failed
+	pending int
+	// Ring buffer with the most recent attestation outcomes, true when confirmed
+	outcomes    []bool
+	outcomeNext int
+	// Ring buffer with the most recent confirmation latencies, in seconds
+	confirmationLatencies []float64
+	confirmationNext      int
+}
+
+// Options configures the metrics created by `NewMetricsWithOptions`
+type Options struct {
+	// Address the server listens on, either a TCP address or a unix domain socket path
+	// prefixed by `unix:`
+	Address string
+	ChainID string
+	// Friendly name (e.g. `mainnet`) used as the value of the network label of every
+	// metric. The chain id is used when empty
+	NetworkName string
+	Logger      Logger
+	// When provided, all the endpoints are served over https
+	TLS TLSFiles
+	// When provided, the `/metrics` and `/status` endpoints require them
+	Auth BasicAuth
+	// Prefixes of every metric name, `validator` and `attestation` respectively when empty
+	Namespace string
+	Subsystem string
+	// Exposed through the build info metric
+	Version string
+	Commit  string
+	// Names of the metrics (without namespace and subsystem, e.g. `signer_balance`) left out
+	// from `/metrics` and from pushes. Recording them is still safe, but naming a metric that
+	// doesn't exist is an error
+	Disabled []string
+	// When provided, only the `/metrics` and `/health` endpoints are registered on it instead
+	// and no server is created, so the address is ignored and the metrics must not be started.
+	// Neither endpoint may be registered on it already
+	Mux *http.ServeMux
+	// Serves the `/debug/reset` endpoint. Strictly a testing aid
+	EnableDebugEndpoints bool
+	// Buckets (in seconds) of the epoch fetch, submission, confirmation and attestation cycle
+	// histograms. Each histogram keeps its own default buckets when empty
+	LatencyBuckets []float64
+	// Timeouts of the server. `DefaultReadHeaderTimeout`, `DefaultReadTimeout` and
+	// `DefaultWriteTimeout` are used respectively when zero
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	// Serves `/metrics` in the OpenMetrics format to the clients asking for it, which is
+	// required to expose exemplars. The Prometheus text format is always served otherwise
+	EnableOpenMetrics bool
+	// Set when the embedding validator simulates its attestations instead of sending them,
+	// so dashboards can tell that nothing reaches the chain
+	DryRun bool
+	// Number of most recent confirmation latencies the median confirmation time is computed
+	// over, `DefaultMedianConfirmationWindow` when zero
+	MedianConfirmationWindow int
+	// Labels with a constant value (e.g. `operator`) added to every validator metric, so a
+	// host running validators for several customers can slice its dashboards by them. They
+	// can't override the network label
+	ConstLabels map[string]string
+}
+
+// NewMetrics creates a new metrics server with the default options, see `NewMetricsWithOptions`
+// to configure it further
+func NewMetrics(serverAddress string, chainID string, logger Logger) (*Metrics, error) {
+	return NewMetricsWithOptions(Options{Address: serverAddress, ChainID: chainID, Logger: logger})
 }
 
-// NewMetrics creates a new metrics server
-func NewMetrics(serverAddress string, chainID string, logger *utils.ZapLogger) *Metrics {
+// NewMetricsWithOptions creates the metrics of the validator along with the server exposing
+// them, configured by the given options
+func NewMetricsWithOptions(opts Options) (*Metrics, error) {
+	if err := opts.TLS.Check(); err != nil {
+		return nil, err
+	}
+	if err := opts.Auth.Check(); err != nil {
+		return nil, err
+	}
+	for i := 1; i < len(opts.LatencyBuckets); i++ {
+		if opts.LatencyBuckets[i] <= opts.LatencyBuckets[i-1] {
+			return nil, errors.New("metrics latency buckets must be in increasing order")
+		}
+	}
+	if err := checkConstLabels(opts.ConstLabels); err != nil {
+		return nil, err
+	}
+	if opts.MedianConfirmationWindow < 0 {
+		return nil, errors.New("metrics median confirmation window must not be negative")
+	}
+	if opts.Mux != nil {
+		if err := checkExternalMux(opts.Mux); err != nil {
+			return nil, err
+		}
+	}
+	medianWindow := opts.MedianConfirmationWindow
+	if medianWindow == 0 {
+		medianWindow = DefaultMedianConfirmationWindow
+	}
+	latencyBuckets := func(defaults ...float64) []float64 {
+		if len(opts.LatencyBuckets) > 0 {
+			return opts.LatencyBuckets
+		}
+		return defaults
+	}
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	subsystem := opts.Subsystem
+	if subsystem == "" {
+		subsystem = DefaultSubsystem
+	}
+
 	registry := prometheus.NewRegistry()
 
+	network := opts.NetworkName
+	if network == "" {
+		network = opts.ChainID
+	}
+
 	m := &Metrics{
-		logger:   logger,
-		network:  chainID,
-		registry: registry,
-		latestBlockNumber: prometheus.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Name: "validator_attestation_starknet_latest_block_number",
-				Help: "The latest block number seen by the validator on the Starknet network",
-			},
-			[]string{"network"},
-		),
-		currentEpochID: prometheus.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Name: "validator_attestation_current_epoch_id",
-				Help: "The ID of the current epoch the validator is participating in",
-			},
-			[]string{"network"},
-		),
-		currentEpochLength: prometheus.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Name: "validator_attestation_current_epoch_length",
-				Help: "The total length (in blocks) of the current epoch",
-			},
-			[]string{"network"},
-		),
-		currentEpochStartingBlockNumber: prometheus.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Name: "validator_attestation_current_epoch_starting_block_number",
-				Help: "The first block number of the current epoch",
-			},
-			[]string{"network"},
-		),
-		currentEpochAssignedBlockNumber: prometheus.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Name: "validator_attestation_current_epoch_assigned_block_number",
-				Help: "The specific block number within the current epoch for which the validator is assigned to attest",
-			},
-			[]string{"network"},
-		),
-		lastAttestationTimestamp: prometheus.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Name: "validator_attestation_last_attestation_timestamp_seconds",
-				Help: "The Unix timestamp (in seconds) of the last successful attestation submission",
-			},
-			[]string{"network"},
-		),
-		attestationSubmittedCount: prometheus.NewCounterVec(
-			prometheus.CounterOpts{
-				Name: "validator_attestation_attestation_submitted_count",
-				Help: "The total number of attestations submitted by the validator since startup",
-			},
-			[]string{"network"},
-		),
-		attestationFailureCount: prometheus.NewCounterVec(
-			prometheus.CounterOpts{
-				Name: "validator_attestation_attestation_failure_count",
-				Help: "The total number of attestation transaction submission failures encountered by the validator since startup",
-			},
-			[]string{"network"},
-		),
-		attestationConfirmedCount: prometheus.NewCounterVec(
-			prometheus.CounterOpts{
-				Name: "validator_attestation_attestation_confirmed_count",
-				Help: "The total number of attestations that have been confirmed on the network since validator startup",
-			},
-			[]string{"network"},
-		),
-		signerBalance: prometheus.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Name: "validator_attestation_signer_balance",
-				Help: "The balance of the account that signs the attestation after each attest transaction",
-			},
-			[]string{"network"},
-		),
-		signerBalanceBelowThreshold: prometheus.NewGaugeVec(
-			prometheus.GaugeOpts{
-				Name: "validator_attestation_signer_below_threshold",
-				Help: "Set to one if the account that signs the attestation has it's balance below certain threshold",
-			},
-			[]string{"network"},
-		),
+		exporter: &exporter{
+			tls:       opts.TLS,
+			logger:    opts.Logger,
+			namespace: namespace,
+			registry:  registry,
+			buildInfo: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "build_info",
+					Help:      "Always set to one, labeled by the version and commit of the running validator",
+				},
+				[]string{"network", "version", "commit"},
+			),
+			dryRunMode: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "dry_run_mode",
+					Help:      "Set to one if the validator simulates its attestations instead of sending them to the chain",
+				},
+				[]string{"network"},
+			),
+			rpcEndpointInfo: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "rpc_endpoint_info",
+					Help:      "Always set to one, labeled by the host of the RPC provider the validator is connected to",
+				},
+				[]string{"network", "host"},
+			),
+			latestBlockNumber: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "starknet_latest_block_number",
+					Help:      "The latest block number seen by the validator on the Starknet network",
+				},
+				[]string{"network"},
+			),
+			headBlockAge: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "head_block_age_seconds",
+					Help:      "The time (in seconds) elapsed since the creation of the latest block seen by the validator",
+				},
+				[]string{"network"},
+			),
+			blockLag: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "block_lag",
+					Help:      "The number of blocks between the node's latest block and the last block processed by the validator",
+				},
+				[]string{"network"},
+			),
+			reorgDetectedCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "reorg_detected_count",
+					Help:      "The total number of chain reorgs notified by the node since validator startup",
+				},
+				[]string{"network"},
+			),
+			reorgDepth: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "reorg_depth",
+					Help:      "The number of blocks reorganised by the last chain reorg",
+				},
+				[]string{"network"},
+			),
+			currentEpochID: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "current_epoch_id",
+					Help:      "The ID of the current epoch the validator is participating in",
+				},
+				[]string{"network"},
+			),
+			currentEpochLength: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "current_epoch_length",
+					Help:      "The total length (in blocks) of the current epoch",
+				},
+				[]string{"network"},
+			),
+			currentEpochStartingBlockNumber: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "current_epoch_starting_block_number",
+					Help:      "The first block number of the current epoch",
+				},
+				[]string{"network"},
+			),
+			currentEpochAssignedBlockNumber: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "current_epoch_assigned_block_number",
+					Help:      "The specific block number within the current epoch for which the validator is assigned to attest",
+				},
+				[]string{"network"},
+			),
+			epochInfo: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "epoch_info",
+					Help:      "Always set to one, labeled by the id, starting block and assigned block of the current epoch",
+				},
+				[]string{"network", "epoch_id", "starting_block", "assigned_block"},
+			),
+			blocksSeenInEpoch: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "blocks_seen_in_epoch",
+					Help:      "The number of distinct blocks of the current epoch processed by the validator",
+				},
+				[]string{"network"},
+			),
+			epochTransitionCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "epoch_transition_count",
+					Help:      "The total number of epoch transitions observed by the validator since startup",
+				},
+				[]string{"network"},
+			),
+			epochLengthChangeCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "epoch_length_change_count",
+					Help:      "The total number of times the epoch length changed since validator startup",
+				},
+				[]string{"network"},
+			),
+			epochsAttestedCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "epochs_attested_count",
+					Help:      "The total number of distinct epochs with at least one confirmed attestation since validator startup",
+				},
+				[]string{"network"},
+			),
+			blocksAssignedCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "blocks_assigned_count",
+					Help:      "The total number of blocks the validator was assigned to attest since validator startup",
+				},
+				[]string{"network"},
+			),
+			blocksAttestedCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "blocks_attested_count",
+					Help:      "The total number of assigned blocks with a confirmed attestation since validator startup",
+				},
+				[]string{"network"},
+			),
+			epochFetchDuration: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "epoch_fetch_duration_seconds",
+					Help:      "The time (in seconds) spent fetching the epoch and attestation info from the node",
+					Buckets:   latencyBuckets(0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10),
+				},
+				[]string{"network"},
+			),
+			epochFetchFailureCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "epoch_fetch_failure_count",
+					Help:      "The total number of failed attempts to fetch the epoch and attestation info from the node since validator startup",
+				},
+				[]string{"network"},
+			),
+			blockProcessingDuration: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "block_processing_duration_seconds",
+					Help:      "The time (in seconds) spent handling each new block, from receiving its header until the attestation decision is made",
+					Buckets:   []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+				},
+				[]string{"network"},
+			),
+			attestationWindow: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_window",
+					Help:      "The length (in blocks) of the attestation window as set by the attestation contract",
+				},
+				[]string{"network"},
+			),
+			attestationTargetOffset: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_target_offset",
+					Help:      "The offset (in blocks) from the start of the current epoch of the block the validator derived it must attest to",
+				},
+				[]string{"network"},
+			),
+			blocksUntilWindowClose: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "blocks_until_window_close",
+					Help:      "The number of blocks left until the current attestation window closes",
+				},
+				[]string{"network"},
+			),
+			secondsUntilNextAttestation: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "seconds_until_next_attestation",
+					Help:      "The estimated time (in seconds) until the block the validator is assigned to attest is reached",
+				},
+				[]string{"network"},
+			),
+			assignmentLeadTime: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "assignment_lead_time_seconds",
+					Help:      "The estimated time (in seconds) between learning the assigned block and its arrival, negative when learned after",
+				},
+				[]string{"network"},
+			),
+			lastAttestationTimestamp: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "last_attestation_timestamp_seconds",
+					Help:      "The Unix timestamp (in seconds) of the last successful attestation submission",
+				},
+				[]string{"network"},
+			),
+			lastConfirmedTimestamp: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "last_confirmed_attestation_timestamp_seconds",
+					Help:      "The Unix timestamp (in seconds) of the last attestation confirmed on the network",
+				},
+				[]string{"network"},
+			),
+			attestationSubmittedCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_submitted_count",
+					Help:      "The total number of attestations submitted by the validator since startup",
+				},
+				[]string{"network"},
+			),
+			attestationFailureCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_failure_count",
+					Help:      "The total number of attestation failures encountered by the validator since startup, by reason",
+				},
+				[]string{"network", "reason"},
+			),
+			attestationConfirmedCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_confirmed_count",
+					Help:      "The total number of attestations that have been confirmed on the network since validator startup",
+				},
+				[]string{"network"},
+			),
+			attestationMissedCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_missed_count",
+					Help:      "The total number of attestation windows that closed without a confirmed attestation since validator startup",
+				},
+				[]string{"network"},
+			),
+			attestationLateCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_late_count",
+					Help:      "The total number of confirmed attestation transactions included in a block past the attestation window since validator startup",
+				},
+				[]string{"network"},
+			),
+			consecutiveMissed: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "consecutive_missed_attestations",
+					Help:      "The number of attestations missed in a row, reset on the next confirmed attestation",
+				},
+				[]string{"network"},
+			),
+			pendingAttestations: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "pending_attestations",
+					Help:      "The number of submitted attestation transactions not yet confirmed nor failed",
+				},
+				[]string{"network"},
+			),
+			attestationRetryCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_retry_count",
+					Help:      "The total number of times an attestation transaction was resubmitted after a failed attempt since validator startup",
+				},
+				[]string{"network"},
+			),
+			attestationSkippedNoFunds: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_skipped_insufficient_funds_count",
+					Help:      "The total number of attestation transactions not accepted because the signer balance couldn't cover the fee since validator startup",
+				},
+				[]string{"network"},
+			),
+			attestationResult: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_result_count",
+					Help:      "The total number of attestations by result (submitted, confirmed, failed or missed) since validator startup",
+				},
+				[]string{"network", "result"},
+			),
+			attestationSuccessRatio: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_success_ratio",
+					Help:      "The ratio of confirmed attestations over the most recent attestation outcomes (confirmed or failed)",
+				},
+				[]string{"network"},
+			),
+			attestationConfirmationMedian: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_confirmation_median_seconds",
+					Help:      "The median time (in seconds) it took the most recent attestation transactions to be confirmed",
+				},
+				[]string{"network"},
+			),
+			attestationSubmissionLatency: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_submission_latency_seconds",
+					Help:      "The time (in seconds) from detecting the assigned block to the attestation transaction being accepted by the node",
+					Buckets:   latencyBuckets(0.1, 0.5, 1, 2, 5, 10, 30),
+				},
+				[]string{"network"},
+			),
+			attestationConfirmationLatency: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_confirmation_latency_seconds",
+					Help:      "The time (in seconds) between the attestation transaction submission and its confirmation on the network",
+					Buckets:   latencyBuckets(1, 2, 5, 10, 20, 30, 60, 120),
+				},
+				[]string{"network"},
+			),
+			attestationCycleDuration: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_cycle_duration_seconds",
+					Help:      "The time (in seconds) from detecting the assigned block to the attestation transaction being confirmed on the network",
+					Buckets:   latencyBuckets(5, 10, 20, 30, 60, 120, 300, 600),
+				},
+				[]string{"network"},
+			),
+			attestationFeeSpent: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_fee_spent",
+					Help:      "The total amount of STRK spent on fees by confirmed attestation transactions since validator startup",
+				},
+				[]string{"network"},
+			),
+			attestationTxSize: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestation_tx_size_bytes",
+					Help:      "The size (in bytes) of the serialized attestation transactions submitted to the node",
+					Buckets:   prometheus.ExponentialBuckets(256, 2, 8),
+				},
+				[]string{"network"},
+			),
+			attestationsPerEpoch: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "attestations_per_epoch",
+					Help:      "The number of attestations confirmed in each epoch, observed when the epoch ends",
+					Buckets:   prometheus.LinearBuckets(0, 1, 4),
+				},
+				[]string{"network"},
+			),
+			signerBalance: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "signer_balance",
+					Help:      "The balance of the account that signs the attestation after each attest transaction",
+				},
+				[]string{"network", "address", "address_role"},
+			),
+			signerBalanceDelta: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "signer_balance_delta",
+					Help:      "The change of the balance of the account that signs the attestation since its previous check",
+				},
+				[]string{"network", "address", "address_role"},
+			),
+			signerBalanceUSD: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "signer_balance_usd",
+					Help:      "The balance (in USD) of the account that signs the attestation. Only set when a price provider is configured",
+				},
+				[]string{"network", "address", "address_role"},
+			),
+			signerBalanceKnown: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "signer_balance_known",
+					Help:      "Set to one once the balance of the account that signs the attestation was read, zero while it is unknown",
+				},
+				[]string{"network", "address", "address_role"},
+			),
+			validatorStake: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "validator_stake",
+					Help:      "The amount of STRK staked by the validator for the current epoch",
+				},
+				[]string{"network", "address"},
+			),
+			validatorActive: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "validator_active",
+					Help:      "Set to one if the validator can attest, zero while the staking contract is paused",
+				},
+				[]string{"network"},
+			),
+			chainIDMismatch: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "chain_id_mismatch",
+					Help:      "Set to one if the node runs a different network than the one the staking contract belongs to",
+				},
+				[]string{"network"},
+			),
+			signerBalanceBelowThreshold: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "signer_below_threshold",
+					Help:      "Set to one if the account that signs the attestation has it's balance below certain threshold",
+				},
+				[]string{"network", "address", "address_role"},
+			),
+			signerBelowThresholdSinceEpoch: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "signer_below_threshold_since_epoch",
+					Help:      "The epoch in which the balance of the account that signs the attestation dropped below threshold, only set while below",
+				},
+				[]string{"network", "address", "address_role"},
+			),
+			signerAccountReady: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "signer_account_ready",
+					Help:      "Set to one if the account that signs the attestation is deployed with the expected class hash and can attest",
+				},
+				[]string{"network"},
+			),
+			signerBalanceThreshold: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "signer_balance_threshold",
+					Help:      "The balance (in STRK) below which the account that signs the attestation is considered below threshold",
+				},
+				[]string{"network"},
+			),
+			signerNonce: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "signer_nonce",
+					Help:      "The nonce of the account that signs the attestation, as used by the last submitted attest transaction",
+				},
+				[]string{"network"},
+			),
+			signerSignCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "signer_sign_count",
+					Help:      "The total number of transaction signing requests made to the signer since validator startup",
+				},
+				[]string{"network"},
+			),
+			signerSignFailureCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "signer_sign_failure_count",
+					Help:      "The total number of failed transaction signing requests made to the signer since validator startup",
+				},
+				[]string{"network"},
+			),
+			feeEstimationFailureCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "fee_estimation_failure_count",
+					Help:      "The total number of failed attest transaction fee estimations since validator startup",
+				},
+				[]string{"network"},
+			),
+			maxFeeCap: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "max_fee_cap",
+					Help:      "The highest L2 gas price (in FRI) the last attest transaction accepts to pay",
+				},
+				[]string{"network"},
+			),
+			currentGasPrice: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "current_gas_price",
+					Help:      "The L2 gas price (in FRI) used to estimate the fee of the last attest transaction",
+				},
+				[]string{"network"},
+			),
+			feeTokenInfo: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "fee_token_info",
+					Help:      "Always set to one, labeled by the token the fee of the last attest transaction was estimated in",
+				},
+				[]string{"network", "token"},
+			),
+			rpcRequests: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "rpc_requests_count",
+					Help:      "The total number of JSON-RPC requests issued to the node, by method and status (ok or error)",
+				},
+				[]string{"network", "method", "status"},
+			),
+			rpcReconnectCount: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "rpc_reconnect_count",
+					Help:      "The total number of times the connection to the node dropped and the validator reconnected since startup",
+				},
+				[]string{"network"},
+			),
+			lastError: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: namespace,
+					Subsystem: subsystem,
+					Name:      "last_error",
+					Help:      "Always set to one, labeled by the reason of the most recent attestation failure",
+				},
+				[]string{"network", "reason"},
+			),
+			derived:        newDerivedCollector(namespace, subsystem),
+			healthCheckers: make(map[string]HealthChecker),
+			version:        opts.Version,
+			commit:         opts.Commit,
+			dryRun:         opts.DryRun,
+			medianWindow:   medianWindow,
+		},
+		network:              network,
+		chainID:              opts.ChainID,
+		EnableDebugEndpoints: opts.EnableDebugEndpoints,
+		clock:                time.Now,
+		blocksSeen:           make(map[uint64]struct{}),
+		belowThresholdSince:  make(map[string]uint64),
+		lastBalance:          make(map[string]float64),
+		outcomes:             make([]bool, 0, SuccessRatioWindow),
 	}
 
-	// Register metrics with Prometheus registry
-	registry.MustRegister(
+	m.uptime = newUptimeCollector(namespace, subsystem, m.derived)
+	m.processStart = m.clock()
+
+	// Register metrics with Prometheus registry. The build info, dry-run mode and RPC endpoint
+	// info are kept apart since they are set once and must survive `Reset`
+	m.collectors = []prometheus.Collector{
 		m.latestBlockNumber,
+		m.headBlockAge,
+		m.blockLag,
+		m.reorgDetectedCount,
+		m.reorgDepth,
 		m.currentEpochID,
 		m.currentEpochLength,
 		m.currentEpochStartingBlockNumber,
 		m.currentEpochAssignedBlockNumber,
+		m.epochInfo,
+		m.blocksSeenInEpoch,
+		m.epochTransitionCount,
+		m.epochLengthChangeCount,
+		m.epochsAttestedCount,
+		m.blocksAssignedCount,
+		m.blocksAttestedCount,
+		m.epochFetchDuration,
+		m.epochFetchFailureCount,
+		m.blockProcessingDuration,
+		m.attestationWindow,
+		m.attestationTargetOffset,
+		m.blocksUntilWindowClose,
+		m.secondsUntilNextAttestation,
+		m.assignmentLeadTime,
 		m.lastAttestationTimestamp,
+		m.lastConfirmedTimestamp,
 		m.attestationSubmittedCount,
 		m.attestationFailureCount,
 		m.attestationConfirmedCount,
+		m.attestationMissedCount,
+		m.attestationLateCount,
+		m.consecutiveMissed,
+		m.pendingAttestations,
+		m.attestationRetryCount,
+		m.attestationSkippedNoFunds,
+		m.attestationResult,
+		m.attestationSuccessRatio,
+		m.attestationSubmissionLatency,
+		m.attestationConfirmationLatency,
+		m.attestationConfirmationMedian,
+		m.attestationCycleDuration,
+		m.attestationFeeSpent,
+		m.attestationTxSize,
+		m.attestationsPerEpoch,
 		m.signerBalance,
+		m.signerBalanceUSD,
+		m.signerBalanceKnown,
+		m.signerBalanceDelta,
+		m.validatorStake,
+		m.validatorActive,
+		m.chainIDMismatch,
 		m.signerBalanceBelowThreshold,
+		m.signerBelowThresholdSinceEpoch,
+		m.signerAccountReady,
+		m.signerBalanceThreshold,
+		m.signerNonce,
+		m.signerSignCount,
+		m.signerSignFailureCount,
+		m.feeEstimationFailureCount,
+		m.currentGasPrice,
+		m.maxFeeCap,
+		m.feeTokenInfo,
+		m.rpcRequests,
+		m.rpcReconnectCount,
+		m.lastError,
+		m.derived,
+		m.uptime,
+	}
+	// Disabled metrics are never registered, so they are left out of every gather
+	collectors := append(
+		[]prometheus.Collector{m.buildInfo, m.dryRunMode, m.rpcEndpointInfo}, m.collectors...,
 	)
+	enabled, err := withoutDisabled(namespace, subsystem, opts.Disabled, collectors)
+	if err != nil {
+		return nil, err
+	}
+	registerer := prometheus.WrapRegistererWith(opts.ConstLabels, registry)
+	if err := register(registerer, enabled...); err != nil {
+		return nil, err
+	}
+
+	m.buildInfo.WithLabelValues(m.network, m.version, m.commit).Set(1)
+	m.setDryRunMode()
+	m.derived.add(m)
+	m.refreshers = []func(){m.refreshHeadBlockAge}
 
-	// Create HTTP server
-	mux := http.NewServeMux()
+	mux := opts.Mux
+	external := mux != nil
+	if !external {
+		mux = http.NewServeMux()
+	}
+	m.mux = mux
+	// Only the plain liveness probe is open, the detailed report requires credentials
+	healthReport := opts.Auth.Wrap(http.HandlerFunc(m.serveHealthReport))
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("verbose") == "1" {
+			healthReport.ServeHTTP(w, r)
+			return
+		}
+		m.serveHealth(w, r)
+	})
+	mux.Handle("/metrics", opts.Auth.Wrap(
+		promhttp.HandlerFor(prometheus.GathererFunc(m.gather), promhttp.HandlerOpts{
+			EnableOpenMetrics: opts.EnableOpenMetrics,
+		}),
+	))
+
+	if external {
+		// The endpoints are served as soon as they are registered
+		m.startedAt = m.clock()
+		return m, nil
+	}
+	mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		if !m.ready.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, err := w.Write([]byte("NOT READY"))
+			if err != nil {
+				m.logger.Errorf("Failed to write readiness check response: %v", err)
+			}
+			return
+		}
 		w.WriteHeader(http.StatusOK)
 		_, err := w.Write([]byte("OK"))
 		if err != nil {
-			m.logger.Errorf("Failed to write health check response: %v", err)
+			m.logger.Errorf("Failed to write readiness check response: %v", err)
 		}
 	})
-	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	mux.Handle("/status", opts.Auth.Wrap(http.HandlerFunc(m.serveStatus)))
+	mux.Handle("/debug/reset", opts.Auth.Wrap(http.HandlerFunc(m.serveReset)))
 
 	m.server = &http.Server{
-		Addr:    serverAddress,
-		Handler: mux,
+		Addr:              opts.Address,
+		Handler:           mux,
+		ReadHeaderTimeout: durationOr(opts.ReadHeaderTimeout, DefaultReadHeaderTimeout),
+		ReadTimeout:       durationOr(opts.ReadTimeout, DefaultReadTimeout),
+		WriteTimeout:      durationOr(opts.WriteTimeout, DefaultWriteTimeout),
+	}
+
+	return m, nil
+}
+
+// Endpoints registered on an external mux, the remaining ones are only served by the
+// metrics own server
+var externalMuxEndpoints = []string{"/metrics", "/health"}
+
+// Checks none of the endpoints registered on an external mux is registered there already,
+// which would make the mux panic
+func checkExternalMux(mux *http.ServeMux) error {
+	for _, path := range externalMuxEndpoints {
+		req := &http.Request{Method: http.MethodGet, URL: &url.URL{Path: path}}
+		if _, pattern := mux.Handler(req); pattern == path {
+			return fmt.Errorf("metrics endpoint %s is already registered on the mux", path)
+		}
+	}
+	return nil
+}
+
+// Checks the constant labels are valid Prometheus labels which don't collide with the
+// labels of the validator metrics
+func checkConstLabels(labels map[string]string) error {
+	for name, value := range labels {
+		if !labelNameRegexp.MatchString(name) || strings.HasPrefix(name, "__") {
+			return fmt.Errorf("invalid metrics label name %q", name)
+		}
+		if name == "network" {
+			return errors.New("metrics label network can't be overridden")
+		}
+		if value == "" {
+			return fmt.Errorf("metrics label %s has no value", name)
+		}
+	}
+	return nil
+}
+
+// Returns d, or the fallback when d is zero
+func durationOr(d, fallback time.Duration) time.Duration {
+	if d == 0 {
+		return fallback
+	}
+	return d
+}
+
+// ForNetwork returns the metrics of another network, served through the same server and
+// registry as m so validators on several networks can share a single exporter. Its series
+// are labeled with the given network and its internal state is kept apart. The hooks are
+// copied from m at the time of the call. The `/ready` and `/status` endpoints keep
+// reporting the network of m, and only m should be started. Asking again for a network
+// returns the same metrics
+func (m *Metrics) ForNetwork(network string) *Metrics {
+	n := &Metrics{
+		exporter:      m.exporter,
+		network:       network,
+		chainID:       network,
+		EventHook:     m.EventHook,
+		Notifier:      m.Notifier,
+		PushURL:       m.PushURL,
+		PriceProvider: m.PriceProvider,
+		clock:         m.clock,
+		blocksSeen:    make(map[uint64]struct{}),
+		outcomes:      make([]bool, 0, SuccessRatioWindow),
+
+		RemoteWriteURL:      m.RemoteWriteURL,
+		RemoteWriteAuth:     m.RemoteWriteAuth,
+		belowThresholdSince: make(map[string]uint64),
+		lastBalance:         make(map[string]float64),
+	}
+	if existing, added := n.derived.add(n); !added {
+		return existing
+	}
+	n.buildInfo.WithLabelValues(network, n.version, n.commit).Set(1)
+	n.setDryRunMode()
+
+	n.refreshMu.Lock()
+	n.refreshers = append(n.refreshers, n.refreshHeadBlockAge)
+	n.refreshMu.Unlock()
+	return n
+}
+
+// ChainID returns the chain id of the network the metrics belong to
+func (m *Metrics) ChainID() string {
+	return m.chainID
+}
+
+// SetRPCEndpoint labels the RPC endpoint info with the host of the given provider url.
+// Credentials, path and query are never exported since they often hold an API key
+func (m *Metrics) SetRPCEndpoint(endpoint string) {
+	m.rpcEndpointInfo.DeletePartialMatch(m.labels())
+	m.rpcEndpointInfo.WithLabelValues(m.network, maskEndpoint(endpoint)).Set(1)
+}
+
+// Returns the host of the endpoint, or "invalid" if it can't be parsed
+func maskEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return "invalid"
+	}
+	return u.Host
+}
+
+// Registry returns the registry served through `/metrics`, so additional collectors can be
+// registered and exported along with the validator metrics
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Mux returns the mux serving the validator endpoints, so additional handlers (e.g.
+// `/debug/pprof`) can be served on the same listener. It is the external mux when one was
+// given through the options
+func (m *Metrics) Mux() *http.ServeMux {
+	return m.mux
+}
+
+// Gathers every registered metric, refreshing first the ones derived from the current time
+func (m *Metrics) gather() ([]*dto.MetricFamily, error) {
+	m.refreshMu.Lock()
+	for _, refresh := range m.refreshers {
+		refresh()
+	}
+	m.refreshMu.Unlock()
+
+	return m.registry.Gather()
+}
+
+// Reports for the network whether the validator runs in dry-run mode
+func (m *Metrics) setDryRunMode() {
+	value := 0.0
+	if m.dryRun {
+		value = 1
+	}
+	m.dryRunMode.WithLabelValues(m.network).Set(value)
+}
+
+// Reset clears the value of every metric of the network but the build info, as well as the
+// internal state derived from them, leaving the metrics as if they were just created. Mostly
+// useful for tests that want to reuse the same instance across cases
+func (m *Metrics) Reset() {
+	for _, collector := range m.collectors {
+		if vec, ok := collector.(interface {
+			DeletePartialMatch(labels prometheus.Labels) int
+		}); ok {
+			vec.DeletePartialMatch(m.labels())
+		}
 	}
 
-	return m
+	m.mu.Lock()
+	m.epochSeen = false
+	m.lastEpochID = 0
+	m.lastEpochLen = 0
+	m.attestedSeen = false
+	m.lastAttestedEpochID = 0
+	m.epochStart = 0
+	m.epochEnd = 0
+	m.blocksSeen = make(map[uint64]struct{})
+	m.headTimestamp = time.Time{}
+	m.headBlock = 0
+	m.assignedBlock = 0
+	m.blockTime = 0
+	m.lastConfirmedAt = time.Time{}
+	m.confirmedInEpoch = 0
+	m.missedStreak = 0
+	m.windowFailed = false
+	m.belowThresholdSince = make(map[string]uint64)
+	m.lastBalance = make(map[string]float64)
+	m.pending = 0
+	m.outcomes = m.outcomes[:0]
+	m.outcomeNext = 0
+	m.confirmationLatencies = m.confirmationLatencies[:0]
+	m.confirmationNext = 0
+	m.mu.Unlock()
 }
 
-// Start starts the metrics server
+// Resets the metrics on POST. Answers with 404 unless the debug endpoints are enabled
+func (m *Metrics) serveReset(w http.ResponseWriter, r *http.Request) {
+	if !m.EnableDebugEndpoints {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	m.logger.Infof("Resetting metrics through the debug endpoint")
+	m.Reset()
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		m.logger.Errorf("Failed to write reset response: %v", err)
+	}
+}
+
+// Returns the labels matching every series of the network
+func (m *Metrics) labels() prometheus.Labels {
+	return prometheus.Labels{"network": m.network}
+}
+
+// Start starts the metrics server. It listens on a unix domain socket when the server
+// address has the `unix:` prefix, and on TCP otherwise. It returns `ErrAlreadyRunning` if
+// the server is already running. A stopped server can't be started again
 func (m *Metrics) Start() error {
+	listener, err := m.listen()
+	if err != nil {
+		return err
+	}
+	return m.serve(listener)
+}
+
+// Opens the listener of the server and marks it as running, so a `Stop` from then on
+// shuts it down even if it isn't serving yet
+func (m *Metrics) listen() (net.Listener, error) {
+	if m.server == nil {
+		return nil, ErrExternalMux
+	}
+	m.runMu.Lock()
+	if m.running {
+		m.runMu.Unlock()
+		return nil, ErrAlreadyRunning
+	}
+	if m.stopped {
+		m.runMu.Unlock()
+		return nil, http.ErrServerClosed
+	}
+	m.healthMu.Lock()
+	m.startedAt = m.clock()
+	m.healthMu.Unlock()
+
+	var listener net.Listener
+	var err error
+	if path, ok := m.socketPath(); ok {
+		if err = removeStaleSocket(path); err == nil {
+			listener, err = net.Listen("unix", path)
+		}
+	} else {
+		listener, err = net.Listen("tcp", m.server.Addr)
+	}
+	if err != nil {
+		m.runMu.Unlock()
+		return nil, err
+	}
+	m.running = true
+	m.runMu.Unlock()
+	return listener, nil
+}
+
+// Serves requests on the listener until the server is stopped
+func (m *Metrics) serve(listener net.Listener) error {
+	defer func() {
+		m.runMu.Lock()
+		m.running = false
+		m.runMu.Unlock()
+	}()
+
+	if m.tls.Enabled() {
+		m.logger.Infof("Starting metrics server with TLS on %s", m.server.Addr)
+		return m.server.ServeTLS(listener, m.tls.CertFile, m.tls.KeyFile)
+	}
 	m.logger.Infof("Starting metrics server on %s", m.server.Addr)
-	return m.server.ListenAndServe()
+	return m.server.Serve(listener)
 }
 
-// Stop stops the metrics server
+// Run starts the metrics server and gracefully stops it once ctx is cancelled. It returns
+// nil on a clean shutdown, or the error that prevented the server from running
+func (m *Metrics) Run(ctx context.Context) error {
+	// Listening before waiting on ctx makes sure a cancellation always finds the server
+	// running, so it can't be missed by `Stop`
+	listener, err := m.listen()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- m.serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		if err := m.Stop(context.WithoutCancel(ctx)); err != nil {
+			return err
+		}
+		if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+// Returns the path of the unix domain socket the server listens on, if any
+func (m *Metrics) socketPath() (string, bool) {
+	if m.server == nil {
+		return "", false
+	}
+	return strings.CutPrefix(m.server.Addr, UnixSocketPrefix)
+}
+
+// Removes the socket file left behind by a server which didn't stop cleanly, since it would
+// prevent listening on the same path again. Any other kind of file is kept
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&fs.ModeSocket == 0 {
+		return nil
+	}
+	return os.Remove(path)
+}
+
+// Stop stops the metrics server, waiting for in-flight requests to finish. If the context
+// has no deadline, a default one of `DefaultShutdownTimeout` is used so shutdown never hangs.
+// It does nothing if the server was never started or is already stopped
 func (m *Metrics) Stop(ctx context.Context) error {
-	m.logger.Info("Stopping metrics server")
-	return m.server.Shutdown(ctx)
+	if m.server == nil {
+		return nil
+	}
+	m.runMu.Lock()
+	running := m.running
+	// A server never started can still be started later on
+	if running {
+		m.running = false
+		m.stopped = true
+	}
+	m.runMu.Unlock()
+	if !running {
+		return nil
+	}
+
+	m.logger.Infof("Stopping metrics server")
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultShutdownTimeout)
+		defer cancel()
+	}
+
+	err := m.server.Shutdown(ctx)
+	if err != nil {
+		m.logger.Warnw("Metrics server shutdown deadline exceeded, forcing close", "error", err)
+		err = errors.Join(err, m.server.Close())
+	}
+	if path, ok := m.socketPath(); ok {
+		if rmErr := os.Remove(path); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) {
+			err = errors.Join(err, rmErr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	m.logger.Infof("Metrics server stopped cleanly")
+	return nil
 }
 
-// UpdateLatestBlockNumber updates the latest block number metric
-func (m *Metrics) UpdateLatestBlockNumber(blockNumber uint64) {
-	m.logger.Debugw("UpdateLatestBlockNumber", "blockNumber", blockNumber)
+// Logs the event at debug level and forwards it to the event hook if any. The keys are
+// the `Field` constants, and every event carries the network
+func (m *Metrics) event(name string, keysAndValues ...any) {
+	keysAndValues = append([]any{FieldNetwork, m.network}, keysAndValues...)
+	m.logger.Debugw(name, keysAndValues...)
+	if m.EventHook == nil {
+		return
+	}
+
+	fields := make(map[string]any, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		fields[key] = keysAndValues[i+1]
+	}
+	m.EventHook(name, fields)
+}
+
+// Sends the message through the notifier if any, without blocking the caller
+func (m *Metrics) notify(level notify.Level, message string) {
+	if m.Notifier == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+		if err := m.Notifier.Notify(ctx, level, message); err != nil {
+			m.logger.Warnw("Failed to send notification", "error", err)
+		}
+	}()
+}
+
+// SetReady sets whether the validator is ready, reported through the `/ready` endpoint
+func (m *Metrics) SetReady(ready bool) {
+	m.event("SetReady", FieldReady, ready)
+	m.ready.Store(ready)
+}
+
+// UpdateLatestBlockNumber updates the latest block number metric, as well as the age of the
+// block, which keeps growing on every scrape until a new block is seen
+func (m *Metrics) UpdateLatestBlockNumber(blockNumber uint64, blockTimestamp time.Time) {
+	m.event(
+		"UpdateLatestBlockNumber",
+		FieldBlockNumber, blockNumber, FieldBlockTimestamp, blockTimestamp.Unix(),
+	)
 	m.latestBlockNumber.WithLabelValues(m.network).Set(float64(blockNumber))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.headBlock != 0 && blockNumber > m.headBlock && blockTimestamp.After(m.headTimestamp) {
+		interval := blockTimestamp.Sub(m.headTimestamp) / time.Duration(blockNumber-m.headBlock)
+		if m.blockTime == 0 {
+			m.blockTime = interval
+		} else {
+			// Moving average, so a single slow block doesn't throw the estimate off
+			m.blockTime = (4*m.blockTime + interval) / 5
+		}
+	}
+	if blockNumber > m.headBlock {
+		m.headBlock = blockNumber
+	}
+	m.headTimestamp = blockTimestamp
+	m.updateHeadBlockAge()
+	m.updateUntilNextAttestation()
+
+	if m.epochSeen && blockNumber < m.epochStart {
+		return
+	}
+	// Blocks past the current epoch are kept too, since the first block of an epoch is
+	// processed before its epoch info is known
+	m.blocksSeen[blockNumber] = struct{}{}
+	m.updateBlocksSeen()
+}
+
+// Sets the time elapsed since the latest block was created, if any
+func (m *Metrics) refreshHeadBlockAge() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateHeadBlockAge()
+}
+
+// Must be called with the lock held
+func (m *Metrics) updateHeadBlockAge() {
+	if m.headTimestamp.IsZero() {
+		return
+	}
+	m.headBlockAge.WithLabelValues(m.network).Set(m.clock().Sub(m.headTimestamp).Seconds())
+}
+
+// Sets the estimated time until the assigned block is reached. Once it is, the next assigned
+// block isn't known until the next epoch, so nothing is exported meanwhile. Must be called
+// with the lock held
+func (m *Metrics) updateUntilNextAttestation() {
+	if !m.epochSeen || m.blockTime == 0 || m.headBlock >= m.assignedBlock {
+		m.secondsUntilNextAttestation.DeleteLabelValues(m.network)
+		return
+	}
+	remaining := time.Duration(m.assignedBlock-m.headBlock) * m.blockTime
+	m.secondsUntilNextAttestation.WithLabelValues(m.network).Set(remaining.Seconds())
+}
+
+// Sets how long before its expected arrival the assigned block became known, from the time of
+// the head block and the average time between blocks. Nothing is exported until both are
+// known. Must be called with the lock held
+func (m *Metrics) updateAssignmentLeadTime() {
+	if m.blockTime == 0 || m.headTimestamp.IsZero() {
+		return
+	}
+	blocks := int64(m.assignedBlock) - int64(m.headBlock)
+	expected := m.headTimestamp.Add(time.Duration(blocks) * m.blockTime)
+	m.assignmentLeadTime.WithLabelValues(m.network).Set(expected.Sub(m.clock()).Seconds())
+}
+
+// Sets the number of seen blocks within the current epoch. Must be called with the lock held
+func (m *Metrics) updateBlocksSeen() {
+	seen := 0
+	for block := range m.blocksSeen {
+		if !m.epochSeen || block < m.epochEnd {
+			seen++
+		}
+	}
+	m.blocksSeenInEpoch.WithLabelValues(m.network).Set(float64(seen))
+}
+
+// UpdateBlockLag updates how many blocks the validator is behind the node's latest block
+func (m *Metrics) UpdateBlockLag(lag uint64) {
+	m.event("UpdateBlockLag", FieldBlocks, lag)
+	m.blockLag.WithLabelValues(m.network).Set(float64(lag))
 }
 
-// UpdateEpochInfo updates the epoch-related metrics
+// UpdateEpochInfo updates the epoch-related metrics. Every time the epoch id differs from the
+// previously seen one, an epoch transition is recorded, and likewise for the epoch length
+// which only changes through governance. Processed blocks before the epoch are no longer
+// counted as seen
 func (m *Metrics) UpdateEpochInfo(epochInfo *types.EpochInfo, targetBlock uint64) {
-	m.logger.Debugw("UpdateEpochInfo", "epochInfo", epochInfo, "targetBlock", targetBlock)
+	m.event(
+		"UpdateEpochInfo",
+		FieldEpochID, epochInfo.EpochId,
+		FieldEpochLength, epochInfo.EpochLen,
+		FieldStartingBlock, epochInfo.StartingBlock.Uint64(),
+		FieldAssignedBlock, targetBlock,
+	)
+
+	m.mu.Lock()
+	// The same assignment is reported again when the epoch info is fetched anew
+	newAssignment := !m.epochSeen ||
+		m.lastEpochID != epochInfo.EpochId ||
+		m.assignedBlock != targetBlock
+	if newAssignment {
+		m.blocksAssignedCount.WithLabelValues(m.network).Inc()
+	}
+	if m.epochSeen && m.lastEpochID != epochInfo.EpochId {
+		m.epochTransitionCount.WithLabelValues(m.network).Inc()
+		m.attestationsPerEpoch.WithLabelValues(m.network).Observe(float64(m.confirmedInEpoch))
+		m.confirmedInEpoch = 0
+	}
+	if m.epochSeen && m.lastEpochLen != epochInfo.EpochLen {
+		m.epochLengthChangeCount.WithLabelValues(m.network).Inc()
+	}
+	m.epochSeen = true
+	m.lastEpochID = epochInfo.EpochId
+	m.lastEpochLen = epochInfo.EpochLen
+	m.epochStart = epochInfo.StartingBlock.Uint64()
+	m.epochEnd = m.epochStart + epochInfo.EpochLen
+	m.assignedBlock = targetBlock
+	if newAssignment {
+		m.updateAssignmentLeadTime()
+	}
+	m.updateUntilNextAttestation()
+	for block := range m.blocksSeen {
+		if block < m.epochStart {
+			delete(m.blocksSeen, block)
+		}
+	}
+	m.updateBlocksSeen()
+	m.mu.Unlock()
+
 	m.currentEpochID.WithLabelValues(m.network).Set(float64(epochInfo.EpochId))
 	m.currentEpochLength.WithLabelValues(m.network).Set(float64(epochInfo.EpochLen))
 	m.currentEpochStartingBlockNumber.
 		WithLabelValues(m.network).
 		Set(float64(epochInfo.StartingBlock.Uint64()))
 	m.currentEpochAssignedBlockNumber.WithLabelValues(m.network).Set(float64(targetBlock))
+
+	// Only the current epoch is kept, so stale series don't pile up
+	m.epochInfo.DeletePartialMatch(m.labels())
+	m.epochInfo.WithLabelValues(
+		m.network,
+		strconv.FormatUint(epochInfo.EpochId, 10),
+		strconv.FormatUint(epochInfo.StartingBlock.Uint64(), 10),
+		strconv.FormatUint(targetBlock, 10),
+	).Set(1)
+}
+
+// RecordEpochFetchDuration observes the time it took to fetch the epoch and attestation info
+func (m *Metrics) RecordEpochFetchDuration(d time.Duration) {
+	m.event("RecordEpochFetchDuration", FieldDuration, seconds(d))
+	m.epochFetchDuration.WithLabelValues(m.network).Observe(d.Seconds())
+}
+
+// RecordEpochFetchFailure increments the epoch info fetch failure counter
+func (m *Metrics) RecordEpochFetchFailure() {
+	m.event("RecordEpochFetchFailure")
+	m.epochFetchFailureCount.WithLabelValues(m.network).Inc()
+}
+
+// RecordBlockProcessingDuration observes the time it took to handle a new block
+func (m *Metrics) RecordBlockProcessingDuration(d time.Duration) {
+	m.event("RecordBlockProcessingDuration", FieldDuration, seconds(d))
+	m.blockProcessingDuration.WithLabelValues(m.network).Observe(d.Seconds())
+}
+
+// UpdateAttestationWindow updates the attestation window length metric
+func (m *Metrics) UpdateAttestationWindow(window uint64) {
+	m.event("UpdateAttestationWindow", FieldWindow, window)
+	m.attestationWindow.WithLabelValues(m.network).Set(float64(window))
+}
+
+// RecordAttestationTarget records the block the validator derived it must attest to in the
+// epoch, so it can be cross-checked against what the attestation contract expects
+func (m *Metrics) RecordAttestationTarget(epochID, startingBlock, assignedBlock uint64) {
+	m.event(
+		"RecordAttestationTarget",
+		FieldEpochID, epochID,
+		FieldStartingBlock, startingBlock,
+		FieldAssignedBlock, assignedBlock,
+	)
+	if assignedBlock < startingBlock {
+		m.logger.Warnw(
+			"Assigned block is before the start of the epoch",
+			FieldEpochID, epochID,
+			FieldStartingBlock, startingBlock,
+			FieldAssignedBlock, assignedBlock,
+		)
+		return
+	}
+	m.attestationTargetOffset.WithLabelValues(m.network).Set(float64(assignedBlock - startingBlock))
+}
+
+// UpdateBlocksUntilWindowClose updates how many blocks are left before the attestation
+// window closes
+func (m *Metrics) UpdateBlocksUntilWindowClose(blocks uint64) {
+	m.event("UpdateBlocksUntilWindowClose", FieldBlocks, blocks)
+	m.blocksUntilWindowClose.WithLabelValues(m.network).Set(float64(blocks))
+}
+
+// UpdateSignerBalance sets the balance (in STRK) of the account with the given address and
+// role, either the operational or the rewards one, along with its change since the
+// previous balance of the account
+func (m *Metrics) UpdateSignerBalance(address string, role string, balance float64) {
+	m.event(
+		"UpdateSignerBalance", FieldAddress, address, FieldAddressRole, role, FieldBalance, balance,
+	)
+	m.signerBalance.WithLabelValues(m.network, address, role).Set(balance)
+	m.signerBalanceKnown.WithLabelValues(m.network, address, role).Set(1)
+
+	m.mu.Lock()
+	previous, seen := m.lastBalance[address]
+	m.lastBalance[address] = balance
+	m.mu.Unlock()
+	if seen {
+		m.signerBalanceDelta.WithLabelValues(m.network, address, role).Set(balance - previous)
+	}
+	m.UpdateSignerBalanceUSD(address, role, balance)
+}
+
+// MarkSignerBalanceUnknown reports the balance of the account address and role as unknown,
+// e.g. on startup before it was ever read. Every balance metric of the account is removed
+// until the next balance update, so no below threshold alert fires on a balance never read
+func (m *Metrics) MarkSignerBalanceUnknown(address string, role string) {
+	m.event("MarkSignerBalanceUnknown", FieldAddress, address, FieldAddressRole, role)
+	m.signerBalanceKnown.WithLabelValues(m.network, address, role).Set(0)
+
+	m.mu.Lock()
+	delete(m.lastBalance, address)
+	delete(m.belowThresholdSince, address)
+	m.mu.Unlock()
+	for _, vec := range []*prometheus.GaugeVec{
+		m.signerBalance,
+		m.signerBalanceDelta,
+		m.signerBalanceUSD,
+		m.signerBalanceBelowThreshold,
+		m.signerBelowThresholdSinceEpoch,
+	} {
+		vec.DeleteLabelValues(m.network, address, role)
+	}
+}
+
+// UpdateValidatorStake sets the amount (in STRK) staked by the validator with the given
+// operational address
+func (m *Metrics) UpdateValidatorStake(address string, amount float64) {
+	m.event("UpdateValidatorStake", FieldAddress, address, FieldAmount, amount)
+	m.validatorStake.WithLabelValues(m.network, address).Set(amount)
+}
+
+// UpdateValidatorActive sets whether the validator can attest
+func (m *Metrics) UpdateValidatorActive(active bool) {
+	m.event("UpdateValidatorActive", FieldActive, active)
+	value := 0.0
+	if active {
+		value = 1
+	}
+	m.validatorActive.WithLabelValues(m.network).Set(value)
+}
+
+// UpdateChainIdMismatch sets whether the node runs a different network than the one the
+// staking contract belongs to
+func (m *Metrics) UpdateChainIdMismatch(mismatch bool) {
+	m.event("UpdateChainIdMismatch", FieldMismatch, mismatch)
+	value := 0.0
+	if mismatch {
+		value = 1
+	}
+	m.chainIDMismatch.WithLabelValues(m.network).Set(value)
+}
+
+// RecordAttestationDetected records that the assigned block was reached and the
+// attestation lifecycle started
+func (m *Metrics) RecordAttestationDetected() {
+	m.event("RecordAttestationDetected")
 }
 
-// UpdateSignerBalance set's the signer account balance. If it is too big a default max value is set
-// instead
-func (m *Metrics) UpdateSignerBalance(balance float64) {
-	m.logger.Debugw("UpdateSignerBalancer", "balance", balance)
-	m.signerBalance.WithLabelValues(m.network).Set(balance)
+// RecordAttestationBuilt records that building and signing the attest transaction finished
+func (m *Metrics) RecordAttestationBuilt(start time.Time, err error) {
+	m.event(
+		"RecordAttestationBuilt",
+		FieldDuration, seconds(m.clock().Sub(start)),
+		FieldError, errorField(err),
+	)
 }
 
-// RecordAttestationSubmitted increments the attestation submitted counter
+// RecordAttestationInvoked records that the attest transaction was sent to the node
+func (m *Metrics) RecordAttestationInvoked(start time.Time, txHash string, err error) {
+	m.event(
+		"RecordAttestationInvoked",
+		FieldDuration, seconds(m.clock().Sub(start)),
+		FieldTxHash, txHash,
+		FieldError, errorField(err),
+	)
+}
+
+// RecordAttestationSubmitted increments the attestation submitted counter, as well as the
+// number of pending attestations
 func (m *Metrics) RecordAttestationSubmitted() {
-	m.logger.Debugw("RecordAttestationSubmitted")
+	m.event("RecordAttestationSubmitted")
 	m.attestationSubmittedCount.WithLabelValues(m.network).Inc()
-	m.lastAttestationTimestamp.WithLabelValues(m.network).Set(float64(time.Now().Unix()))
+	m.attestationResult.WithLabelValues(m.network, ResultSubmitted).Inc()
+	m.lastAttestationTimestamp.WithLabelValues(m.network).Set(float64(m.clock().Unix()))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending++
+	m.pendingAttestations.WithLabelValues(m.network).Set(float64(m.pending))
+}
+
+// RecordAttestationFailure increments the attestation failure counter for the given reason
+// and replaces the last error reason with it. The window is reported as missed afterwards,
+// but its result is `failed`
+func (m *Metrics) RecordAttestationFailure(reason string) {
+	m.event("RecordAttestationFailure", FieldReason, reason)
+	m.attestationFailureCount.WithLabelValues(m.network, reason).Inc()
+	m.attestationResult.WithLabelValues(m.network, ResultFailed).Inc()
+	m.lastError.DeletePartialMatch(m.labels())
+	m.lastError.WithLabelValues(m.network, reason).Set(1)
+	m.settlePending()
+
+	m.mu.Lock()
+	m.windowFailed = true
+	m.mu.Unlock()
+	m.notify(
+		notify.LevelCritical, fmt.Sprintf("Attestation failed on %s: %s", m.network, reason),
+	)
+}
+
+// RecordAttestationConfirmed increments the attestation confirmed counter, attaching the
+// transaction hash, if any, as an exemplar. The first confirmation within an epoch also
+// counts the epoch as attested
+func (m *Metrics) RecordAttestationConfirmed(txHash string) {
+	m.event("RecordAttestationConfirmed", FieldTxHash, txHash)
+	confirmed := m.attestationConfirmedCount.WithLabelValues(m.network)
+	if adder, ok := confirmed.(prometheus.ExemplarAdder); ok && txHash != "" {
+		adder.AddWithExemplar(1, prometheus.Labels{"tx_hash": txHash})
+	} else {
+		confirmed.Inc()
+	}
+	m.attestationResult.WithLabelValues(m.network, ResultConfirmed).Inc()
+	now := m.clock()
+	m.lastConfirmedTimestamp.WithLabelValues(m.network).Set(float64(now.Unix()))
+
+	m.recordOutcome(true)
+	m.settlePending()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastConfirmedAt = now
+	if m.epochSeen {
+		m.confirmedInEpoch++
+	}
+	m.missedStreak = 0
+	m.windowFailed = false
+	m.consecutiveMissed.WithLabelValues(m.network).Set(0)
+	if !m.epochSeen || (m.attestedSeen && m.lastAttestedEpochID == m.lastEpochID) {
+		return
+	}
+	m.attestedSeen = true
+	m.lastAttestedEpochID = m.lastEpochID
+	m.epochsAttestedCount.WithLabelValues(m.network).Inc()
+	// A single block is assigned per epoch
+	m.blocksAttestedCount.WithLabelValues(m.network).Inc()
+}
+
+// Adds the attestation outcome to the most recent ones, dropping the oldest once the window
+// is full, and updates the success ratio
+func (m *Metrics) recordOutcome(confirmed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.outcomes) < SuccessRatioWindow {
+		m.outcomes = append(m.outcomes, confirmed)
+	} else {
+		m.outcomes[m.outcomeNext] = confirmed
+	}
+	m.outcomeNext = (m.outcomeNext + 1) % SuccessRatioWindow
+
+	successes := 0
+	for _, outcome := range m.outcomes {
+		if outcome {
+			successes++
+		}
+	}
+	m.attestationSuccessRatio.WithLabelValues(m.network).
+		Set(float64(successes) / float64(len(m.outcomes)))
+}
+
+// RecordAttestationMissed increments the counter of attestation windows which closed without
+// a confirmed attestation, and the streak of attestations missed in a row. Unless a failure
+// was recorded for the window, its result is `missed`
+func (m *Metrics) RecordAttestationMissed() {
+	m.event("RecordAttestationMissed")
+	m.attestationMissedCount.WithLabelValues(m.network).Inc()
+	m.recordOutcome(false)
+
+	m.mu.Lock()
+	m.missedStreak++
+	m.consecutiveMissed.WithLabelValues(m.network).Set(float64(m.missedStreak))
+	failed := m.windowFailed
+	m.windowFailed = false
+	m.mu.Unlock()
+
+	if failed {
+		return
+	}
+	m.attestationResult.WithLabelValues(m.network, ResultMissed).Inc()
+	m.notify(notify.LevelCritical, fmt.Sprintf("Attestation missed on %s", m.network))
+}
+
+// RecordAttestationLate increments the counter of attestations that landed past the window
+func (m *Metrics) RecordAttestationLate() {
+	m.event("RecordAttestationLate")
+	m.attestationLateCount.WithLabelValues(m.network).Inc()
+}
+
+// RecordAttestationRetry increments the attestation retry counter. The previous attempt
+// failed, so it is no longer pending
+func (m *Metrics) RecordAttestationRetry() {
+	m.event("RecordAttestationRetry")
+	m.attestationRetryCount.WithLabelValues(m.network).Inc()
+	m.settlePending()
+}
+
+// RecordAttestationSkippedInsufficientFunds increments the counter of attestations not
+// accepted because the signer balance couldn't cover the fee
+func (m *Metrics) RecordAttestationSkippedInsufficientFunds() {
+	m.event("RecordAttestationSkippedInsufficientFunds")
+	m.attestationSkippedNoFunds.WithLabelValues(m.network).Inc()
+}
+
+// Decrements the number of pending attestations, which never goes below zero since
+// attestations can fail without ever being submitted
+func (m *Metrics) settlePending() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pending == 0 {
+		return
+	}
+	m.pending--
+	m.pendingAttestations.WithLabelValues(m.network).Set(float64(m.pending))
+}
+
+// RecordAttestationSubmissionLatency observes the time it took from detecting the assigned
+// block until the attestation transaction got accepted by the node
+func (m *Metrics) RecordAttestationSubmissionLatency(d time.Duration) {
+	m.event("RecordAttestationSubmissionLatency", FieldDuration, seconds(d))
+	m.attestationSubmissionLatency.WithLabelValues(m.network).Observe(d.Seconds())
+}
+
+// RecordAttestationConfirmationLatency observes the time it took for a submitted attestation
+// transaction to be confirmed
+func (m *Metrics) RecordAttestationConfirmationLatency(d time.Duration) {
+	m.event("RecordAttestationConfirmationLatency", FieldDuration, seconds(d))
+	m.attestationConfirmationLatency.WithLabelValues(m.network).Observe(d.Seconds())
+	m.recordConfirmationLatency(d)
+}
+
+// Adds the confirmation latency to the most recent ones, dropping the oldest once the window
+// is full, and updates the median confirmation time
+func (m *Metrics) recordConfirmationLatency(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.confirmationLatencies) < m.medianWindow {
+		m.confirmationLatencies = append(m.confirmationLatencies, d.Seconds())
+	} else {
+		m.confirmationLatencies[m.confirmationNext] = d.Seconds()
+	}
+	m.confirmationNext = (m.confirmationNext + 1) % m.medianWindow
+
+	sorted := slices.Clone(m.confirmationLatencies)
+	slices.Sort(sorted)
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + median) / 2
+	}
+	m.attestationConfirmationMedian.WithLabelValues(m.network).Set(median)
+}
+
+// RecordAttestationCycle observes the time it took from detecting the assigned block until
+// the attestation transaction got confirmed, covering the whole attestation
+func (m *Metrics) RecordAttestationCycle(d time.Duration) {
+	m.event("RecordAttestationCycle", FieldDuration, seconds(d))
+	m.attestationCycleDuration.WithLabelValues(m.network).Observe(d.Seconds())
+}
+
+// RecordAttestationFee adds the fee (in STRK) paid by a confirmed attestation transaction
+func (m *Metrics) RecordAttestationFee(amount float64) {
+	m.event("RecordAttestationFee", FieldAmount, amount)
+	m.attestationFeeSpent.WithLabelValues(m.network).Add(amount)
+}
+
+// RecordSignerBalanceAboveThreshold sets the value to 0 for the account address and role
+func (m *Metrics) RecordSignerBalanceAboveThreshold(address string, role string) {
+	m.event("RecordSignerBalanceAboveThreshold", FieldAddress, address, FieldAddressRole, role)
+	m.signerBalanceBelowThreshold.WithLabelValues(m.network, address, role).Set(0)
+
+	m.mu.Lock()
+	delete(m.belowThresholdSince, address)
+	m.mu.Unlock()
+	m.signerBelowThresholdSinceEpoch.DeleteLabelValues(m.network, address, role)
+}
+
+// RecordAttestationTxSize observes the size (in bytes) of a submitted attestation transaction
+func (m *Metrics) RecordAttestationTxSize(bytes int) {
+	m.event("RecordAttestationTxSize", FieldBytes, bytes)
+	m.attestationTxSize.WithLabelValues(m.network).Observe(float64(bytes))
+}
+
+// RecordSignerBalanceBelowThreshold sets the value to 1 for the account address and role,
+// together with the epoch in which the balance dropped below threshold
+func (m *Metrics) RecordSignerBalanceBelowThreshold(address string, role string) {
+	m.event("RecordSignerBalanceBelowThreshold", FieldAddress, address, FieldAddressRole, role)
+	m.signerBalanceBelowThreshold.WithLabelValues(m.network, address, role).Set(1)
+
+	// The epoch is kept until the balance goes back above threshold, and is unknown until
+	// the first epoch is seen
+	m.mu.Lock()
+	since, below := m.belowThresholdSince[address]
+	if !below && m.epochSeen {
+		since, below = m.lastEpochID, true
+		m.belowThresholdSince[address] = since
+	}
+	m.mu.Unlock()
+	if below {
+		m.signerBelowThresholdSinceEpoch.WithLabelValues(m.network, address, role).Set(float64(since))
+	}
+	m.notify(
+		notify.LevelWarning,
+		fmt.Sprintf(
+			"Account %s (%s) balance is below threshold on %s", address, role, m.network,
+		),
+	)
 }
 
-// RecordAttestationFailure increments the attestation failure counter
-func (m *Metrics) RecordAttestationFailure() {
-	m.logger.Debugw("RecordAttestationFailure")
-	m.attestationFailureCount.WithLabelValues(m.network).Inc()
+// UpdateSignerBalanceThreshold sets the configured signer balance threshold
+func (m *Metrics) UpdateSignerBalanceThreshold(threshold float64) {
+	m.event("UpdateSignerBalanceThreshold", FieldThreshold, threshold)
+	m.signerBalanceThreshold.WithLabelValues(m.network).Set(threshold)
 }
 
-// RecordAttestationConfirmed increments the attestation confirmed counter
-func (m *Metrics) RecordAttestationConfirmed() {
-	m.logger.Debugw("RecordAttestationConfirmed")
-	m.attestationConfirmedCount.WithLabelValues(m.network).Inc()
+// UpdateSignerAccountReady sets whether the signer account is deployed with the expected
+// class hash and can attest
+func (m *Metrics) UpdateSignerAccountReady(ready bool) {
+	m.event("UpdateSignerAccountReady", FieldReady, ready)
+	value := 0.0
+	if ready {
+		value = 1
+	}
+	m.signerAccountReady.WithLabelValues(m.network).Set(value)
+}
+
+// UpdateSignerNonce sets the signer account nonce
+func (m *Metrics) UpdateSignerNonce(nonce uint64) {
+	m.event("UpdateSignerNonce", FieldNonce, nonce)
+	m.signerNonce.WithLabelValues(m.network).Set(float64(nonce))
+}
+
+// RecordSignerSign increments the signing requests counter, and the failures one when the
+// signing didn't succeed
+func (m *Metrics) RecordSignerSign(ok bool) {
+	m.event("RecordSignerSign", FieldOK, ok)
+	m.signerSignCount.WithLabelValues(m.network).Inc()
+	if !ok {
+		m.signerSignFailureCount.WithLabelValues(m.network).Inc()
+	}
+}
+
+// RecordFeeEstimationFailure increments the fee estimation failures counter
+func (m *Metrics) RecordFeeEstimationFailure() {
+	m.event("RecordFeeEstimationFailure")
+	m.feeEstimationFailureCount.WithLabelValues(m.network).Inc()
+}
+
+// UpdateGasPrice sets the gas price used by the last attest transaction
+func (m *Metrics) UpdateGasPrice(price float64) {
+	m.event("UpdateGasPrice", FieldPrice, price)
+	m.currentGasPrice.WithLabelValues(m.network).Set(price)
+}
+
+// UpdateMaxFeeCap sets the highest gas price the last attest transaction accepts to pay
+func (m *Metrics) UpdateMaxFeeCap(feeCap float64) {
+	m.event("UpdateMaxFeeCap", FieldPrice, feeCap)
+	m.maxFeeCap.WithLabelValues(m.network).Set(feeCap)
+}
+
+// UpdateFeeToken sets the token the fee of the last attest transaction was estimated in,
+// replacing the previous one
+func (m *Metrics) UpdateFeeToken(token string) {
+	m.event("UpdateFeeToken", FieldToken, token)
+	m.feeTokenInfo.DeletePartialMatch(m.labels())
+	m.feeTokenInfo.WithLabelValues(m.network, token).Set(1)
+}
+
+// RecordRPCRequest increments the JSON-RPC request counter for the method and its outcome
+func (m *Metrics) RecordRPCRequest(method string, ok bool) {
+	m.event("RecordRPCRequest", FieldMethod, method, FieldOK, ok)
+	status := "ok"
+	if !ok {
+		status = "error"
+	}
+	m.rpcRequests.WithLabelValues(m.network, method, status).Inc()
 }
 
-// RecordSignerBalanceAboveThreshold sets the value to 0
-func (m *Metrics) RecordSignerBalanceAboveThreshold() {
-	m.logger.Debug("RecordSignerBalanceAboveThreshold")
-	m.signerBalanceBelowThreshold.WithLabelValues(m.network).Set(0)
+// RecordReorg increments the chain reorg counter and sets the depth of the last reorg
+func (m *Metrics) RecordReorg(depth uint64) {
+	m.event("RecordReorg", FieldDepth, depth)
+	m.reorgDetectedCount.WithLabelValues(m.network).Inc()
+	m.reorgDepth.WithLabelValues(m.network).Set(float64(depth))
 }
 
-// RecordSignerBalanceBelowThreshold sets the value to 1
-func (m *Metrics) RecordSignerBalanceBelowThreshold() {
-	m.logger.Debug("RecordSignerBalanceBelowThreshold")
-	m.signerBalanceBelowThreshold.WithLabelValues(m.network).Set(1)
+// RecordRPCReconnect increments the node reconnection counter
+func (m *Metrics) RecordRPCReconnect() {
+	m.event("RecordRPCReconnect")
+	m.rpcReconnectCount.WithLabelValues(m.network).Inc()
 }
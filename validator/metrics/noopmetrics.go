@@ -1,6 +1,10 @@
 package metrics
 
-import "github.com/NethermindEth/starknet-staking-v2/validator/types"
+import (
+	"time"
+
+	"github.com/NethermindEth/starknet-staking-v2/validator/types"
+)
 
 var _ Tracer = (*NoOpMetrics)(nil)
 
@@ -10,18 +14,88 @@ func NewNoOpMetrics() *NoOpMetrics {
 	return &NoOpMetrics{}
 }
 
-func (m *NoOpMetrics) UpdateLatestBlockNumber(blockNumber uint64) {}
+func (m *NoOpMetrics) SetReady(ready bool) {}
+
+func (m *NoOpMetrics) UpdateLatestBlockNumber(blockNumber uint64, blockTimestamp time.Time) {}
+
+func (m *NoOpMetrics) UpdateBlockLag(lag uint64) {}
 
 func (m *NoOpMetrics) UpdateEpochInfo(epochInfo *types.EpochInfo, targetBlock uint64) {}
 
-func (m *NoOpMetrics) UpdateSignerBalance(balance float64) {}
+func (m *NoOpMetrics) RecordEpochFetchDuration(d time.Duration) {}
+
+func (m *NoOpMetrics) RecordEpochFetchFailure() {}
+
+func (m *NoOpMetrics) RecordBlockProcessingDuration(d time.Duration) {}
+
+func (m *NoOpMetrics) UpdateAttestationWindow(window uint64) {}
+
+func (m *NoOpMetrics) RecordAttestationTarget(epochID, startingBlock, assignedBlock uint64) {}
+
+func (m *NoOpMetrics) UpdateBlocksUntilWindowClose(blocks uint64) {}
+
+func (m *NoOpMetrics) UpdateSignerBalance(address string, role string, balance float64) {}
+
+func (m *NoOpMetrics) MarkSignerBalanceUnknown(address string, role string) {}
+
+func (m *NoOpMetrics) UpdateValidatorStake(address string, amount float64) {}
+
+func (m *NoOpMetrics) UpdateValidatorActive(active bool) {}
+
+func (m *NoOpMetrics) UpdateChainIdMismatch(mismatch bool) {}
+
+func (m *NoOpMetrics) RecordAttestationDetected() {}
+
+func (m *NoOpMetrics) RecordAttestationBuilt(start time.Time, err error) {}
+
+func (m *NoOpMetrics) RecordAttestationInvoked(start time.Time, txHash string, err error) {}
 
 func (m *NoOpMetrics) RecordAttestationSubmitted() {}
 
-func (m *NoOpMetrics) RecordAttestationFailure() {}
+func (m *NoOpMetrics) RecordAttestationFailure(reason string) {}
+
+func (m *NoOpMetrics) RecordAttestationConfirmed(txHash string) {}
+
+func (m *NoOpMetrics) RecordAttestationMissed() {}
+
+func (m *NoOpMetrics) RecordAttestationLate() {}
+
+func (m *NoOpMetrics) RecordAttestationRetry() {}
+
+func (m *NoOpMetrics) RecordAttestationSkippedInsufficientFunds() {}
+
+func (m *NoOpMetrics) RecordAttestationSubmissionLatency(d time.Duration) {}
+
+func (m *NoOpMetrics) RecordAttestationConfirmationLatency(d time.Duration) {}
+
+func (m *NoOpMetrics) RecordAttestationCycle(d time.Duration) {}
+
+func (m *NoOpMetrics) RecordAttestationFee(amount float64) {}
+
+func (m *NoOpMetrics) RecordAttestationTxSize(bytes int) {}
+
+func (m *NoOpMetrics) RecordSignerBalanceAboveThreshold(address string, role string) {}
+
+func (m *NoOpMetrics) RecordSignerBalanceBelowThreshold(address string, role string) {}
+
+func (m *NoOpMetrics) UpdateSignerBalanceThreshold(threshold float64) {}
+
+func (m *NoOpMetrics) UpdateSignerAccountReady(ready bool) {}
+
+func (m *NoOpMetrics) UpdateSignerNonce(nonce uint64) {}
+
+func (m *NoOpMetrics) RecordSignerSign(ok bool) {}
+
+func (m *NoOpMetrics) RecordFeeEstimationFailure() {}
+
+func (m *NoOpMetrics) UpdateGasPrice(price float64) {}
+
+func (m *NoOpMetrics) UpdateMaxFeeCap(feeCap float64) {}
+
+func (m *NoOpMetrics) UpdateFeeToken(token string) {}
 
-func (m *NoOpMetrics) RecordAttestationConfirmed() {}
+func (m *NoOpMetrics) RecordRPCRequest(method string, ok bool) {}
 
-func (m *NoOpMetrics) RecordSignerBalanceAboveThreshold() {}
+func (m *NoOpMetrics) RecordRPCReconnect() {}
 
-func (m *NoOpMetrics) RecordSignerBalanceBelowThreshold() {}
+func (m *NoOpMetrics) RecordReorg(depth uint64) {}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"context"
+	"time"
+)
+
+// How long the price provider is given to report the current price
+const priceTimeout = 10 * time.Second
+
+// PriceProvider reports the current price of one STRK in USD
+type PriceProvider interface {
+	PriceUSD(ctx context.Context) (float64, error)
+}
+
+// UpdateSignerBalanceUSD sets the value in USD of the account balance (in STRK), using the
+// current price from the price provider. It does nothing if there is no provider
+func (m *Metrics) UpdateSignerBalanceUSD(address string, role string, balance float64) {
+	if m.PriceProvider == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), priceTimeout)
+	defer cancel()
+	price, err := m.PriceProvider.PriceUSD(ctx)
+	if err != nil {
+		m.logger.Warnw("Failed to get the STRK price", "error", err)
+		return
+	}
+
+	m.event(
+		"UpdateSignerBalanceUSD",
+		FieldAddress, address, FieldAddressRole, role, FieldBalance, balance, FieldPrice, price,
+	)
+	m.signerBalanceUSD.WithLabelValues(m.network, address, role).Set(balance * price)
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// StartPush pushes the registry to the pushgateway at `PushURL` every interval until
+// ctx is cancelled. Pushes are grouped by the namespace as job and the network, so
+// validators on different networks don't overwrite each other.
+// Useful when Prometheus cannot scrape the validator, e.g. when running behind NAT
+func (m *Metrics) StartPush(ctx context.Context, interval time.Duration) error {
+	if m.PushURL == "" {
+		return errors.New("pushgateway url is not set")
+	}
+	if interval <= 0 {
+		return errors.New("push interval must be positive")
+	}
+
+	pusher := push.New(m.PushURL, m.namespace).
+		Gatherer(prometheus.GathererFunc(m.gatherWithoutNetwork)).
+		Grouping("network", m.network)
+
+	m.logger.Infof("Pushing metrics to %s every %s", m.PushURL, interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := pusher.PushContext(ctx); err != nil && ctx.Err() == nil {
+				m.logger.Warnw("Failed to push metrics", "url", m.PushURL, "error", err)
+			}
+		}
+	}
+}
+
+// The pushgateway rejects metrics containing a grouping label, so the network label is
+// removed from every metric. The pushgateway adds it back from the grouping key.
+// Disabled metrics and the metrics of other networks are not pushed
+func (m *Metrics) gatherWithoutNetwork() ([]*dto.MetricFamily, error) {
+	families, err := m.gather()
+	if err != nil {
+		return nil, err
+	}
+	pushed := families[:0]
+	for _, family := range families {
+		metrics := family.GetMetric()[:0]
+		for _, metric := range family.GetMetric() {
+			if network, ok := m.withoutNetwork(metric); ok && network == m.network {
+				metrics = append(metrics, metric)
+			}
+		}
+		if len(metrics) > 0 {
+			family.Metric = metrics
+			pushed = append(pushed, family)
+		}
+	}
+	return pushed, nil
+}
+
+// Removes the network label from the metric, returning its value
+func (m *Metrics) withoutNetwork(metric *dto.Metric) (string, bool) {
+	var network string
+	var found bool
+	labels := metric.GetLabel()[:0]
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == "network" {
+			network, found = label.GetValue(), true
+			continue
+		}
+		labels = append(labels, label)
+	}
+	metric.Label = labels
+	return network, found
+}
@@ -0,0 +1,215 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// How long a single remote write request is given to complete
+const remoteWriteTimeout = 10 * time.Second
+
+// A single remote write series: its labels, including the metric name, and one sample
+type remoteSeries struct {
+	labels []*dto.LabelPair
+	value  float64
+}
+
+// StartRemoteWrite ships the metrics of the network to the Prometheus remote write endpoint
+// at `RemoteWriteURL` every interval until ctx is cancelled, authenticating with
+// `RemoteWriteAuth` when set. Useful for managed platforms (e.g. Grafana Cloud) that can
+// neither scrape the validator nor receive pushes
+func (m *Metrics) StartRemoteWrite(ctx context.Context, interval time.Duration) error {
+	if m.RemoteWriteURL == "" {
+		return errors.New("remote write url is not set")
+	}
+	if interval <= 0 {
+		return errors.New("remote write interval must be positive")
+	}
+	if err := m.RemoteWriteAuth.Check(); err != nil {
+		return err
+	}
+
+	m.logger.Infof("Writing metrics to %s every %s", maskEndpoint(m.RemoteWriteURL), interval)
+	client := &http.Client{Timeout: remoteWriteTimeout}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := m.remoteWrite(ctx, client); err != nil && ctx.Err() == nil {
+				m.logger.Warnw(
+					"Failed to write metrics",
+					"url", maskEndpoint(m.RemoteWriteURL),
+					"error", err,
+				)
+			}
+		}
+	}
+}
+
+// Sends the current value of every metric of the network in a single request
+func (m *Metrics) remoteWrite(ctx context.Context, client *http.Client) error {
+	families, err := m.gather()
+	if err != nil {
+		return err
+	}
+	body := snappy.Encode(nil, encodeWriteRequest(m.remoteSeries(families), m.clock()))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.RemoteWriteURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if m.RemoteWriteAuth.Enabled() {
+		req.SetBasicAuth(m.RemoteWriteAuth.Username, m.RemoteWriteAuth.Password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf(
+			"remote write endpoint answered %s: %s", resp.Status, strings.TrimSpace(string(message)),
+		)
+	}
+	return nil
+}
+
+// Flattens the families into remote write series the way Prometheus stores them, e.g. a
+// histogram becomes its `_bucket`, `_sum` and `_count` series. Only the metrics of the
+// network are kept
+func (m *Metrics) remoteSeries(families []*dto.MetricFamily) []remoteSeries {
+	var series []remoteSeries
+	for _, family := range families {
+		name := family.GetName()
+		for _, metric := range family.GetMetric() {
+			if !m.ofNetwork(metric) {
+				continue
+			}
+			add := func(suffix string, value float64, extra ...*dto.LabelPair) {
+				series = append(series, remoteSeries{
+					labels: withName(name+suffix, metric.GetLabel(), extra...),
+					value:  value,
+				})
+			}
+
+			switch family.GetType() {
+			case dto.MetricType_COUNTER:
+				add("", metric.GetCounter().GetValue())
+			case dto.MetricType_GAUGE:
+				add("", metric.GetGauge().GetValue())
+			case dto.MetricType_UNTYPED:
+				add("", metric.GetUntyped().GetValue())
+			case dto.MetricType_SUMMARY:
+				summary := metric.GetSummary()
+				for _, quantile := range summary.GetQuantile() {
+					add("", quantile.GetValue(), label("quantile", formatFloat(quantile.GetQuantile())))
+				}
+				add("_sum", summary.GetSampleSum())
+				add("_count", float64(summary.GetSampleCount()))
+			case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+				histogram := metric.GetHistogram()
+				for _, bucket := range histogram.GetBucket() {
+					add(
+						"_bucket",
+						float64(bucket.GetCumulativeCount()),
+						label("le", formatFloat(bucket.GetUpperBound())),
+					)
+				}
+				add("_bucket", float64(histogram.GetSampleCount()), label("le", "+Inf"))
+				add("_sum", histogram.GetSampleSum())
+				add("_count", float64(histogram.GetSampleCount()))
+			}
+		}
+	}
+	return series
+}
+
+// Tells whether the metric belongs to the network
+func (m *Metrics) ofNetwork(metric *dto.Metric) bool {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == "network" {
+			return label.GetValue() == m.network
+		}
+	}
+	return false
+}
+
+// Returns the labels together with the metric name, sorted by name as remote write requires
+func withName(name string, labels []*dto.LabelPair, extra ...*dto.LabelPair) []*dto.LabelPair {
+	all := make([]*dto.LabelPair, 0, len(labels)+len(extra)+1)
+	all = append(all, label("__name__", name))
+	all = append(all, labels...)
+	all = append(all, extra...)
+	slices.SortFunc(all, func(a, b *dto.LabelPair) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return all
+}
+
+func label(name, value string) *dto.LabelPair {
+	return &dto.LabelPair{Name: &name, Value: &value}
+}
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// Encodes the series as a remote write `WriteRequest` protobuf message, every series with a
+// single sample taken at the given time:
+//
+//	WriteRequest { repeated TimeSeries timeseries = 1; }
+//	TimeSeries   { repeated Label labels = 1; repeated Sample samples = 2; }
+//	Label        { string name = 1; string value = 2; }
+//	Sample       { double value = 1; int64 timestamp = 2; }
+func encodeWriteRequest(series []remoteSeries, at time.Time) []byte {
+	var request []byte
+	for _, s := range series {
+		var timeseries []byte
+		for _, l := range s.labels {
+			var encoded []byte
+			encoded = protowire.AppendTag(encoded, 1, protowire.BytesType)
+			encoded = protowire.AppendString(encoded, l.GetName())
+			encoded = protowire.AppendTag(encoded, 2, protowire.BytesType)
+			encoded = protowire.AppendString(encoded, l.GetValue())
+
+			timeseries = protowire.AppendTag(timeseries, 1, protowire.BytesType)
+			timeseries = protowire.AppendBytes(timeseries, encoded)
+		}
+
+		var sample []byte
+		sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+		sample = protowire.AppendFixed64(sample, math.Float64bits(s.value))
+		sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+		sample = protowire.AppendVarint(sample, uint64(at.UnixMilli()))
+		timeseries = protowire.AppendTag(timeseries, 2, protowire.BytesType)
+		timeseries = protowire.AppendBytes(timeseries, sample)
+
+		request = protowire.AppendTag(request, 1, protowire.BytesType)
+		request = protowire.AppendBytes(request, timeseries)
+	}
+	return request
+}
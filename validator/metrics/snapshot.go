@@ -0,0 +1,161 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Snapshot holds the current value of the main validator metrics. When several signers
+// are tracked, the balance is the sum of all of them and the below threshold flag is set
+// if any of them is below
+type Snapshot struct {
+	Network               string  `json:"network"`
+	ChainID               string  `json:"chain_id"`
+	LatestBlockNumber     uint64  `json:"latest_block_number"`
+	EpochID               uint64  `json:"epoch_id"`
+	EpochLength           uint64  `json:"epoch_length"`
+	EpochStartingBlock    uint64  `json:"epoch_starting_block"`
+	AssignedBlock         uint64  `json:"assigned_block"`
+	SignerBalance         float64 `json:"signer_balance"`
+	SignerBelowThreshold  bool    `json:"signer_below_threshold"`
+	AttestationsSubmitted uint64  `json:"attestations_submitted"`
+	AttestationsConfirmed uint64  `json:"attestations_confirmed"`
+	AttestationsFailed    uint64  `json:"attestations_failed"`
+	AttestationsMissed    uint64  `json:"attestations_missed"`
+}
+
+// Serves the current snapshot as JSON
+func (m *Metrics) serveStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(m.Snapshot()); err != nil {
+		m.logger.Errorf("Failed to write status response: %v", err)
+	}
+}
+
+// Snapshot returns the current metric values. They are read from the same collectors
+// served through `/metrics` so both views are always consistent
+func (m *Metrics) Snapshot() Snapshot {
+	return Snapshot{
+		Network:               m.network,
+		ChainID:               m.chainID,
+		LatestBlockNumber:     uint64(m.valueOf(m.latestBlockNumber)),
+		EpochID:               uint64(m.valueOf(m.currentEpochID)),
+		EpochLength:           uint64(m.valueOf(m.currentEpochLength)),
+		EpochStartingBlock:    uint64(m.valueOf(m.currentEpochStartingBlockNumber)),
+		AssignedBlock:         uint64(m.valueOf(m.currentEpochAssignedBlockNumber)),
+		SignerBalance:         m.valueOf(m.signerBalance),
+		SignerBelowThreshold:  m.valueOf(m.signerBalanceBelowThreshold) > 0,
+		AttestationsSubmitted: uint64(m.valueOf(m.attestationSubmittedCount)),
+		AttestationsConfirmed: uint64(m.valueOf(m.attestationConfirmedCount)),
+		AttestationsFailed:    uint64(m.valueOf(m.attestationFailureCount)),
+		AttestationsMissed:    uint64(m.valueOf(m.attestationMissedCount)),
+	}
+}
+
+// StartPeriodicLog logs a one line summary of the current snapshot at info level every
+// interval until ctx is cancelled. Handy to share the validator state through its logs
+// when Prometheus is not reachable
+func (m *Metrics) StartPeriodicLog(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return errors.New("periodic log interval must be positive")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			m.logSnapshot()
+		}
+	}
+}
+
+// StartSignalDump logs a one line summary of the current snapshot at info level every time
+// the process receives SIGUSR1 (e.g. `kill -USR1 <pid>`), until ctx is cancelled. Handy on
+// hosts where the metrics port can't be reached interactively
+func (m *Metrics) StartSignalDump(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+	defer signal.Stop(signals)
+
+	m.dumpOnSignal(ctx, signals)
+}
+
+// Logs the snapshot every time a signal is received, until ctx is cancelled
+func (m *Metrics) dumpOnSignal(ctx context.Context, signals <-chan os.Signal) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			m.logSnapshot()
+		}
+	}
+}
+
+func (m *Metrics) logSnapshot() {
+	m.logger.Infof("Metrics summary: %s", m.Snapshot())
+}
+
+// Formats the snapshot as a single line
+func (s Snapshot) String() string {
+	return fmt.Sprintf(
+		"network=%s latest_block=%d epoch=%d assigned_block=%d submitted=%d confirmed=%d"+
+			" failed=%d missed=%d signer_balance=%g signer_below_threshold=%t",
+		s.Network,
+		s.LatestBlockNumber,
+		s.EpochID,
+		s.AssignedBlock,
+		s.AttestationsSubmitted,
+		s.AttestationsConfirmed,
+		s.AttestationsFailed,
+		s.AttestationsMissed,
+		s.SignerBalance,
+		s.SignerBelowThreshold,
+	)
+}
+
+// Returns the sum of all the gauge or counter series of the collector labeled with the
+// validator network. Unlike `WithLabelValues`, it doesn't create the series if missing
+func (m *Metrics) valueOf(collector prometheus.Collector) float64 {
+	metricsCh := make(chan prometheus.Metric)
+	go func() {
+		collector.Collect(metricsCh)
+		close(metricsCh)
+	}()
+
+	var value float64
+	for metric := range metricsCh {
+		var sample dto.Metric
+		if err := metric.Write(&sample); err != nil {
+			m.logger.Errorf("Failed to read metric %s: %v", metric.Desc(), err)
+			continue
+		}
+		if !hasLabel(&sample, "network", m.network) {
+			continue
+		}
+		value += sample.GetGauge().GetValue() + sample.GetCounter().GetValue()
+	}
+	return value
+}
+
+func hasLabel(sample *dto.Metric, name, value string) bool {
+	for _, label := range sample.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue() == value
+		}
+	}
+	return false
+}
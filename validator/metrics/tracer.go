@@ -1,16 +1,53 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/NethermindEth/starknet-staking-v2/validator/types"
 )
 
 type Tracer interface {
-	UpdateLatestBlockNumber(blockNumber uint64)
+	SetReady(ready bool)
+	UpdateLatestBlockNumber(blockNumber uint64, blockTimestamp time.Time)
+	UpdateBlockLag(lag uint64)
 	UpdateEpochInfo(epochInfo *types.EpochInfo, targetBlock uint64)
-	UpdateSignerBalance(balance float64)
+	RecordEpochFetchDuration(d time.Duration)
+	RecordEpochFetchFailure()
+	RecordBlockProcessingDuration(d time.Duration)
+	UpdateAttestationWindow(window uint64)
+	RecordAttestationTarget(epochID, startingBlock, assignedBlock uint64)
+	UpdateBlocksUntilWindowClose(blocks uint64)
+	UpdateSignerBalance(address string, role string, balance float64)
+	MarkSignerBalanceUnknown(address string, role string)
+	UpdateValidatorStake(address string, amount float64)
+	UpdateValidatorActive(active bool)
+	UpdateChainIdMismatch(mismatch bool)
+	RecordAttestationDetected()
+	RecordAttestationBuilt(start time.Time, err error)
+	RecordAttestationInvoked(start time.Time, txHash string, err error)
 	RecordAttestationSubmitted()
-	RecordAttestationFailure()
-	RecordAttestationConfirmed()
-	RecordSignerBalanceAboveThreshold()
-	RecordSignerBalanceBelowThreshold()
+	RecordAttestationFailure(reason string)
+	RecordAttestationConfirmed(txHash string)
+	RecordAttestationMissed()
+	RecordAttestationLate()
+	RecordAttestationRetry()
+	RecordAttestationSkippedInsufficientFunds()
+	RecordAttestationSubmissionLatency(d time.Duration)
+	RecordAttestationConfirmationLatency(d time.Duration)
+	RecordAttestationCycle(d time.Duration)
+	RecordAttestationFee(amount float64)
+	RecordAttestationTxSize(bytes int)
+	RecordSignerBalanceAboveThreshold(address string, role string)
+	RecordSignerBalanceBelowThreshold(address string, role string)
+	UpdateSignerBalanceThreshold(threshold float64)
+	UpdateSignerAccountReady(ready bool)
+	UpdateSignerNonce(nonce uint64)
+	RecordSignerSign(ok bool)
+	RecordFeeEstimationFailure()
+	UpdateGasPrice(price float64)
+	UpdateMaxFeeCap(feeCap float64)
+	UpdateFeeToken(token string)
+	RecordRPCRequest(method string, ok bool)
+	RecordRPCReconnect()
+	RecordReorg(depth uint64)
 }
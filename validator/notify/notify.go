@@ -0,0 +1,16 @@
+package notify
+
+import "context"
+
+type Level string
+
+const (
+	LevelInfo     Level = "info"
+	LevelWarning  Level = "warning"
+	LevelCritical Level = "critical"
+)
+
+// Notifier sends a message about a validator event to an external service
+type Notifier interface {
+	Notify(ctx context.Context, level Level, message string) error
+}
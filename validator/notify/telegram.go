@@ -0,0 +1,141 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	telegramAPIURL = "https://api.telegram.org"
+	// Identical messages sent within this interval are dropped
+	DefaultRateLimit = 10 * time.Minute
+)
+
+var _ Notifier = (*TelegramNotifier)(nil)
+
+// Sends notifications to a Telegram chat through a bot
+type TelegramNotifier struct {
+	botToken  string
+	chatID    string
+	apiURL    string
+	client    *http.Client
+	rateLimit time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time
+}
+
+func NewTelegramNotifier(botToken, chatID string) *TelegramNotifier {
+	return &TelegramNotifier{
+		botToken:  botToken,
+		chatID:    chatID,
+		apiURL:    telegramAPIURL,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		rateLimit: DefaultRateLimit,
+		now:       time.Now,
+		lastSent:  make(map[string]time.Time),
+	}
+}
+
+type telegramMessage struct {
+	ChatID string `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+type telegramResponse struct {
+	Ok          bool   `json:"ok"`
+	Description string `json:"description"`
+}
+
+func (t *TelegramNotifier) Notify(ctx context.Context, level Level, message string) error {
+	text := fmt.Sprintf("[%s] %s", strings.ToUpper(string(level)), message)
+	reserved, previous, ok := t.shouldSend(text)
+	if !ok {
+		return nil
+	}
+	if err := t.send(ctx, text); err != nil {
+		// Only a delivered message is rate limited, so a failed one is retried on the next alert
+		t.release(text, reserved, previous)
+		return err
+	}
+	return nil
+}
+
+// Sends the text to the chat through the Telegram bot api
+func (t *TelegramNotifier) send(ctx context.Context, text string) error {
+	body, err := json.Marshal(telegramMessage{ChatID: t.chatID, Text: text})
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("%s/bot%s/sendMessage", t.apiURL, t.botToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		// The parse error would contain the bot token
+		return errors.New("cannot build the telegram request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		// Don't leak the bot token through the request url
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return errors.Errorf("cannot reach the telegram api: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var telegramResp telegramResponse
+	if err := json.NewDecoder(resp.Body).Decode(&telegramResp); err != nil {
+		return errors.Errorf("cannot decode telegram response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || !telegramResp.Ok {
+		return errors.Errorf(
+			"telegram api returned status %d: %s", resp.StatusCode, telegramResp.Description,
+		)
+	}
+	return nil
+}
+
+// Returns false if the same message was already sent, or is being sent, within the rate limit
+// interval. Otherwise reserves the slot of the message by marking it sent now, so concurrent
+// identical alerts are dropped, and returns that time along with the one it replaces
+func (t *TelegramNotifier) shouldSend(text string) (time.Time, time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	last, ok := t.lastSent[text]
+	if ok && now.Sub(last) < t.rateLimit {
+		return time.Time{}, time.Time{}, false
+	}
+	t.lastSent[text] = now
+	return now, last, true
+}
+
+// Gives back the slot reserved at the given time for a message that couldn't be sent,
+// restoring the time it was previously sent at, unless it was reserved again since
+func (t *TelegramNotifier) release(text string, reserved, previous time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.lastSent[text].Equal(reserved) {
+		return
+	}
+	if previous.IsZero() {
+		delete(t.lastSent, text)
+		return
+	}
+	t.lastSent[text] = previous
+}
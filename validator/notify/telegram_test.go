@@ -0,0 +1,133 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mockTelegramServer(t *testing.T, received *[]telegramMessage, ok bool) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/botsome-token/sendMessage", r.URL.Path)
+
+		var msg telegramMessage
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		*received = append(*received, msg)
+
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		err := json.NewEncoder(w).Encode(telegramResponse{Ok: ok, Description: "chat not found"})
+		require.NoError(t, err)
+	}))
+}
+
+func TestTelegramNotifier(t *testing.T) {
+	t.Run("Message is sent to the configured chat", func(t *testing.T) {
+		var received []telegramMessage
+		server := mockTelegramServer(t, &received, true)
+		defer server.Close()
+
+		notifier := NewTelegramNotifier("some-token", "1234")
+		notifier.apiURL = server.URL
+
+		err := notifier.Notify(t.Context(), LevelWarning, "balance below threshold")
+		require.NoError(t, err)
+		require.Equal(t, []telegramMessage{
+			{ChatID: "1234", Text: "[WARNING] balance below threshold"},
+		}, received)
+	})
+
+	t.Run("Error when telegram rejects the message", func(t *testing.T) {
+		var received []telegramMessage
+		server := mockTelegramServer(t, &received, false)
+		defer server.Close()
+
+		notifier := NewTelegramNotifier("some-token", "1234")
+		notifier.apiURL = server.URL
+
+		err := notifier.Notify(t.Context(), LevelCritical, "attestation failed")
+		require.ErrorContains(t, err, "chat not found")
+	})
+
+	t.Run("A message that failed to send isn't rate limited", func(t *testing.T) {
+		var received []telegramMessage
+		server := mockTelegramServer(t, &received, false)
+		defer server.Close()
+
+		notifier := NewTelegramNotifier("some-token", "1234")
+		notifier.apiURL = server.URL
+		notifier.now = func() time.Time { return time.Unix(1_700_000_000, 0) }
+
+		require.Error(t, notifier.Notify(t.Context(), LevelCritical, "attestation failed"))
+		require.Error(t, notifier.Notify(t.Context(), LevelCritical, "attestation failed"))
+		require.Len(t, received, 2)
+	})
+
+	t.Run("Identical messages are rate limited", func(t *testing.T) {
+		var received []telegramMessage
+		server := mockTelegramServer(t, &received, true)
+		defer server.Close()
+
+		now := time.Unix(1_700_000_000, 0)
+		notifier := NewTelegramNotifier("some-token", "1234")
+		notifier.apiURL = server.URL
+		notifier.now = func() time.Time { return now }
+
+		require.NoError(t, notifier.Notify(t.Context(), LevelCritical, "attestation failed"))
+		require.NoError(t, notifier.Notify(t.Context(), LevelCritical, "attestation failed"))
+		// A different message is not affected
+		require.NoError(t, notifier.Notify(t.Context(), LevelWarning, "balance below threshold"))
+		require.Len(t, received, 2)
+
+		now = now.Add(DefaultRateLimit)
+		require.NoError(t, notifier.Notify(t.Context(), LevelCritical, "attestation failed"))
+		require.Len(t, received, 3)
+	})
+
+	t.Run("Identical messages are dropped while the first one is being sent", func(t *testing.T) {
+		var (
+			mu       sync.Mutex
+			received int
+		)
+		unblock := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			received++
+			first := received == 1
+			mu.Unlock()
+			if first {
+				<-unblock
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(telegramResponse{Ok: true}))
+		}))
+		defer server.Close()
+
+		notifier := NewTelegramNotifier("some-token", "1234")
+		notifier.apiURL = server.URL
+
+		sent := make(chan error)
+		go func() {
+			sent <- notifier.Notify(t.Context(), LevelCritical, "attestation failed")
+		}()
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return received == 1
+		}, time.Second, time.Millisecond)
+
+		for range 3 {
+			require.NoError(t, notifier.Notify(t.Context(), LevelCritical, "attestation failed"))
+		}
+		close(unblock)
+		require.NoError(t, <-sent)
+		require.Equal(t, 1, received)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/NethermindEth/juno/utils"
+	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
 	"github.com/NethermindEth/starknet.go/client"
 	"github.com/NethermindEth/starknet.go/rpc"
 	"github.com/cockroachdb/errors"
@@ -54,3 +55,21 @@ func SubscribeToBlockHeaders[Logger utils.Logger](wsProviderUrl string, logger L
 	logger.Infof("Subscribed to new block header. Subscription ID: %s", clientSubscription.ID())
 	return wsProvider, headersFeed, clientSubscription, nil
 }
+
+// Logs and records a chain reorg notified through the block headers subscription. The
+// depth is the number of blocks between the first and last reorganised blocks, both included
+func RecordReorg[Logger utils.Logger](
+	logger Logger, reorg *client.ReorgEvent, tracer metrics.Tracer,
+) {
+	var depth uint64
+	if reorg.EndBlockNum >= reorg.StartBlockNum {
+		depth = reorg.EndBlockNum - reorg.StartBlockNum + 1
+	}
+	logger.Warnw(
+		"Chain reorg detected",
+		"start block number", reorg.StartBlockNum,
+		"end block number", reorg.EndBlockNum,
+		"depth", depth,
+	)
+	tracer.RecordReorg(depth)
+}
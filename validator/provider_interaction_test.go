@@ -6,6 +6,8 @@ import (
 
 	"github.com/NethermindEth/juno/utils"
 	"github.com/NethermindEth/starknet-staking-v2/validator"
+	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
+	"github.com/NethermindEth/starknet.go/client"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -95,3 +97,22 @@ func TestBlockHeaderSubscription(t *testing.T) {
 		t.Logf("Ignoring tests that require env variables: %s", err)
 	}
 }
+
+type reorgTracer struct {
+	metrics.NoOpMetrics
+	depths []uint64
+}
+
+func (r *reorgTracer) RecordReorg(depth uint64) {
+	r.depths = append(r.depths, depth)
+}
+
+func TestRecordReorg(t *testing.T) {
+	logger := utils.NewNopZapLogger()
+	tracer := &reorgTracer{}
+
+	validator.RecordReorg(logger, &client.ReorgEvent{StartBlockNum: 100, EndBlockNum: 102}, tracer)
+	validator.RecordReorg(logger, &client.ReorgEvent{StartBlockNum: 100, EndBlockNum: 100}, tracer)
+
+	require.Equal(t, []uint64{3, 1}, tracer.depths)
+}
@@ -146,10 +146,20 @@ func (s *ExternalSigner) GetTransactionStatus(transactionHash *felt.Felt) (
 	return s.Provider.GetTransactionStatus(s.ctx, transactionHash)
 }
 
+func (s *ExternalSigner) GetTransactionReceipt(transactionHash *felt.Felt) (
+	*rpc.TransactionReceiptWithBlockInfo, error,
+) {
+	return s.Provider.TransactionReceipt(s.ctx, transactionHash)
+}
+
 func (s *ExternalSigner) BlockWithTxHashes(blockID rpc.BlockID) (any, error) {
 	return s.Provider.BlockWithTxHashes(s.ctx, blockID)
 }
 
+func (s *ExternalSigner) BlockNumber() (uint64, error) {
+	return s.Provider.BlockNumber(s.ctx)
+}
+
 func (s *ExternalSigner) Call(
 	call rpc.FunctionCall, blockId rpc.BlockID,
 ) ([]*felt.Felt, error) {
@@ -168,6 +178,10 @@ func (s *ExternalSigner) Nonce() (*felt.Felt, error) {
 	return s.Provider.Nonce(s.ctx, rpc.WithBlockTag("pending"), s.Address().Felt())
 }
 
+func (s *ExternalSigner) ClassHash() (*felt.Felt, error) {
+	return s.Provider.ClassHashAt(s.ctx, rpc.WithBlockTag("latest"), s.Address().Felt())
+}
+
 func SignInvokeTx(invokeTxnV3 *rpc.BroadcastInvokeTxnV3, chainId *felt.Felt, externalSignerUrl string) error {
 	signResp, err := HashAndSignTx(invokeTxnV3, chainId, externalSignerUrl)
 	if err != nil {
@@ -73,6 +73,12 @@ func (s *InternalSigner) GetTransactionStatus(transactionHash *felt.Felt) (
 	return s.Account.Provider.GetTransactionStatus(s.ctx, transactionHash)
 }
 
+func (s *InternalSigner) GetTransactionReceipt(transactionHash *felt.Felt) (
+	*rpc.TransactionReceiptWithBlockInfo, error,
+) {
+	return s.Account.Provider.TransactionReceipt(s.ctx, transactionHash)
+}
+
 func (s *InternalSigner) BuildAttestTransaction(
 	blockhash *types.BlockHash,
 ) (rpc.BroadcastInvokeTxnV3, error) {
@@ -162,6 +168,10 @@ func (s *InternalSigner) BlockWithTxHashes(blockID rpc.BlockID) (any, error) {
 	return s.Account.Provider.BlockWithTxHashes(s.ctx, blockID)
 }
 
+func (s *InternalSigner) BlockNumber() (uint64, error) {
+	return s.Account.Provider.BlockNumber(s.ctx)
+}
+
 func (s *InternalSigner) Address() *types.Address {
 	return (*types.Address)(s.Account.Address)
 }
@@ -173,3 +183,7 @@ func (s *InternalSigner) ValidationContracts() *types.ValidationContracts {
 func (s *InternalSigner) Nonce() (*felt.Felt, error) {
 	return s.Account.Nonce(s.ctx)
 }
+
+func (s *InternalSigner) ClassHash() (*felt.Felt, error) {
+	return s.Account.Provider.ClassHashAt(s.ctx, rpc.WithBlockTag("latest"), s.Account.Address)
+}
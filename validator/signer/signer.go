@@ -17,6 +17,7 @@ import (
 type Signer interface {
 	// Methods from Starknet.go Account implementation
 	GetTransactionStatus(transactionHash *felt.Felt) (*rpc.TxnStatusResult, error)
+	GetTransactionReceipt(transactionHash *felt.Felt) (*rpc.TransactionReceiptWithBlockInfo, error)
 
 	BuildAttestTransaction(blockHash *types.BlockHash) (rpc.BroadcastInvokeTxnV3, error)
 	EstimateFee(txn *rpc.BroadcastInvokeTxnV3) (rpc.FeeEstimation, error)
@@ -25,9 +26,11 @@ type Signer interface {
 
 	Call(call rpc.FunctionCall, blockId rpc.BlockID) ([]*felt.Felt, error)
 	BlockWithTxHashes(blockID rpc.BlockID) (any, error)
+	BlockNumber() (uint64, error)
 
 	// Property Access
 	Nonce() (*felt.Felt, error)
+	ClassHash() (*felt.Felt, error)
 	Address() *types.Address
 	ValidationContracts() *types.ValidationContracts
 }
@@ -85,14 +88,40 @@ func FetchAttestWindow[S Signer](signer S) (uint64, error) {
 	return result[0].Uint64(), nil
 }
 
+// Returns whether the staking contract is paused, in which case attestations are refused
+func FetchStakingPaused[S Signer](signer S) (bool, error) {
+	result, err := signer.Call(
+		rpc.FunctionCall{
+			ContractAddress:    signer.ValidationContracts().Staking.Felt(),
+			EntryPointSelector: utils.GetSelectorFromNameFelt("is_paused"),
+			Calldata:           []*felt.Felt{},
+		},
+		rpc.BlockID{Tag: "latest"},
+	)
+	if err != nil {
+		return false, entrypointInternalError("is_paused", err)
+	}
+
+	if len(result) != 1 {
+		return false, entrypointResponseError("is_paused", result)
+	}
+
+	return !result[0].IsZero(), nil
+}
+
 // For near future when tracking validator's balance
 func FetchValidatorBalance[S Signer](signer S) (types.Balance, error) {
+	return FetchBalance(signer, signer.Address())
+}
+
+// Returns the STRK balance of any account, e.g. the one receiving the staking rewards
+func FetchBalance[S Signer](signer S, address *types.Address) (types.Balance, error) {
 	StrkTokenContract := types.AddressFromString(constants.STRK_CONTRACT_ADDRESS)
 	result, err := signer.Call(
 		rpc.FunctionCall{
 			ContractAddress:    StrkTokenContract.Felt(),
 			EntryPointSelector: utils.GetSelectorFromNameFelt("balance_of"),
-			Calldata:           []*felt.Felt{signer.Address().Felt()},
+			Calldata:           []*felt.Felt{address.Felt()},
 		},
 		rpc.BlockID{Tag: "latest"},
 	)
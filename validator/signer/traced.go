@@ -0,0 +1,94 @@
+package signer
+
+import (
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
+	"github.com/NethermindEth/starknet.go/rpc"
+)
+
+var _ Signer = (*TracedSigner)(nil)
+
+// Wraps a signer, recording every JSON-RPC request it issues to the node as well as every
+// transaction signing
+type TracedSigner struct {
+	Signer
+	tracer metrics.Tracer
+}
+
+func NewTracedSigner(signer Signer, tracer metrics.Tracer) *TracedSigner {
+	return &TracedSigner{
+		Signer: signer,
+		tracer: tracer,
+	}
+}
+
+func (s *TracedSigner) GetTransactionStatus(transactionHash *felt.Felt) (
+	*rpc.TxnStatusResult, error,
+) {
+	status, err := s.Signer.GetTransactionStatus(transactionHash)
+	s.tracer.RecordRPCRequest("starknet_getTransactionStatus", err == nil)
+	return status, err
+}
+
+func (s *TracedSigner) GetTransactionReceipt(transactionHash *felt.Felt) (
+	*rpc.TransactionReceiptWithBlockInfo, error,
+) {
+	receipt, err := s.Signer.GetTransactionReceipt(transactionHash)
+	s.tracer.RecordRPCRequest("starknet_getTransactionReceipt", err == nil)
+	return receipt, err
+}
+
+func (s *TracedSigner) EstimateFee(txn *rpc.BroadcastInvokeTxnV3) (rpc.FeeEstimation, error) {
+	estimate, err := s.Signer.EstimateFee(txn)
+	s.tracer.RecordRPCRequest("starknet_estimateFee", err == nil)
+	if err != nil {
+		s.tracer.RecordFeeEstimationFailure()
+	}
+	return estimate, err
+}
+
+func (s *TracedSigner) SignTransaction(txn *rpc.BroadcastInvokeTxnV3) (
+	*rpc.BroadcastInvokeTxnV3, error,
+) {
+	signed, err := s.Signer.SignTransaction(txn)
+	s.tracer.RecordSignerSign(err == nil)
+	return signed, err
+}
+
+func (s *TracedSigner) InvokeTransaction(txn *rpc.BroadcastInvokeTxnV3) (
+	*rpc.AddInvokeTransactionResponse, error,
+) {
+	resp, err := s.Signer.InvokeTransaction(txn)
+	s.tracer.RecordRPCRequest("starknet_addInvokeTransaction", err == nil)
+	return resp, err
+}
+
+func (s *TracedSigner) Call(call rpc.FunctionCall, blockId rpc.BlockID) ([]*felt.Felt, error) {
+	result, err := s.Signer.Call(call, blockId)
+	s.tracer.RecordRPCRequest("starknet_call", err == nil)
+	return result, err
+}
+
+func (s *TracedSigner) BlockWithTxHashes(blockID rpc.BlockID) (any, error) {
+	block, err := s.Signer.BlockWithTxHashes(blockID)
+	s.tracer.RecordRPCRequest("starknet_getBlockWithTxHashes", err == nil)
+	return block, err
+}
+
+func (s *TracedSigner) BlockNumber() (uint64, error) {
+	blockNumber, err := s.Signer.BlockNumber()
+	s.tracer.RecordRPCRequest("starknet_blockNumber", err == nil)
+	return blockNumber, err
+}
+
+func (s *TracedSigner) Nonce() (*felt.Felt, error) {
+	nonce, err := s.Signer.Nonce()
+	s.tracer.RecordRPCRequest("starknet_getNonce", err == nil)
+	return nonce, err
+}
+
+func (s *TracedSigner) ClassHash() (*felt.Felt, error) {
+	classHash, err := s.Signer.ClassHash()
+	s.tracer.RecordRPCRequest("starknet_getClassHashAt", err == nil)
+	return classHash, err
+}
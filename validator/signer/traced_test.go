@@ -0,0 +1,117 @@
+package signer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/NethermindEth/juno/core/felt"
+	"github.com/NethermindEth/starknet-staking-v2/mocks"
+	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
+	"github.com/NethermindEth/starknet-staking-v2/validator/signer"
+	"github.com/NethermindEth/starknet.go/rpc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type rpcRequest struct {
+	method string
+	ok     bool
+}
+
+type rpcTracer struct {
+	metrics.NoOpMetrics
+	requests              []rpcRequest
+	signs                 []bool
+	feeEstimationFailures int
+}
+
+func (r *rpcTracer) RecordRPCRequest(method string, ok bool) {
+	r.requests = append(r.requests, rpcRequest{method: method, ok: ok})
+}
+
+func (r *rpcTracer) RecordSignerSign(ok bool) {
+	r.signs = append(r.signs, ok)
+}
+
+func (r *rpcTracer) RecordFeeEstimationFailure() {
+	r.feeEstimationFailures++
+}
+
+func TestTracedSigner(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockSigner := mocks.NewMockSigner(mockCtrl)
+
+	t.Run("Successful request is recorded as ok", func(t *testing.T) {
+		tracer := &rpcTracer{}
+		tracedSigner := signer.NewTracedSigner(mockSigner, tracer)
+
+		nonce := new(felt.Felt).SetUint64(7)
+		mockSigner.EXPECT().Nonce().Return(nonce, nil)
+
+		result, err := tracedSigner.Nonce()
+		require.NoError(t, err)
+		require.Equal(t, nonce, result)
+		require.Equal(t, []rpcRequest{{method: "starknet_getNonce", ok: true}}, tracer.requests)
+	})
+
+	t.Run("Failed request is recorded as error", func(t *testing.T) {
+		tracer := &rpcTracer{}
+		tracedSigner := signer.NewTracedSigner(mockSigner, tracer)
+
+		expectedErr := errors.New("some rpc error")
+		mockSigner.EXPECT().
+			Call(rpc.FunctionCall{}, rpc.BlockID{Tag: "latest"}).
+			Return(nil, expectedErr)
+
+		_, err := tracedSigner.Call(rpc.FunctionCall{}, rpc.BlockID{Tag: "latest"})
+		require.ErrorIs(t, err, expectedErr)
+		require.Equal(t, []rpcRequest{{method: "starknet_call", ok: false}}, tracer.requests)
+	})
+
+	t.Run("Signing is recorded apart from RPC requests", func(t *testing.T) {
+		tracer := &rpcTracer{}
+		tracedSigner := signer.NewTracedSigner(mockSigner, tracer)
+
+		txn := &rpc.BroadcastInvokeTxnV3{}
+		mockSigner.EXPECT().SignTransaction(txn).Return(txn, nil)
+		mockSigner.EXPECT().SignTransaction(txn).Return(nil, errors.New("signer unreachable"))
+
+		_, err := tracedSigner.SignTransaction(txn)
+		require.NoError(t, err)
+		_, err = tracedSigner.SignTransaction(txn)
+		require.Error(t, err)
+		require.Equal(t, []bool{true, false}, tracer.signs)
+		require.Empty(t, tracer.requests)
+	})
+
+	t.Run("Failed fee estimation is recorded apart", func(t *testing.T) {
+		tracer := &rpcTracer{}
+		tracedSigner := signer.NewTracedSigner(mockSigner, tracer)
+
+		txn := &rpc.BroadcastInvokeTxnV3{}
+		mockSigner.EXPECT().EstimateFee(txn).Return(rpc.FeeEstimation{}, nil)
+		mockSigner.EXPECT().EstimateFee(txn).Return(rpc.FeeEstimation{}, errors.New("some rpc error"))
+
+		_, err := tracedSigner.EstimateFee(txn)
+		require.NoError(t, err)
+		_, err = tracedSigner.EstimateFee(txn)
+		require.Error(t, err)
+		require.Equal(t, 1, tracer.feeEstimationFailures)
+		require.Equal(t, []rpcRequest{
+			{method: "starknet_estimateFee", ok: true},
+			{method: "starknet_estimateFee", ok: false},
+		}, tracer.requests)
+	})
+
+	t.Run("Non RPC methods are not recorded", func(t *testing.T) {
+		tracer := &rpcTracer{}
+		tracedSigner := signer.NewTracedSigner(mockSigner, tracer)
+
+		mockSigner.EXPECT().Address().Return(nil)
+
+		tracedSigner.Address()
+		require.Empty(t, tracer.requests)
+	})
+}
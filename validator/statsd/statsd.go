@@ -0,0 +1,103 @@
+package statsd
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
+)
+
+// Prefix of every metric name sent to statsd
+const DefaultPrefix = "validator.attestation"
+
+// Metric names, without prefix
+const (
+	MetricSubmitted     = "submitted"
+	MetricConfirmed     = "confirmed"
+	MetricFailed        = "failed"
+	MetricSignerBalance = "signer_balance"
+)
+
+var _ metrics.Tracer = (*Tracer)(nil)
+
+// Wraps a metrics tracer, additionally mirroring the core metrics to a statsd server over
+// udp: the submitted, confirmed and failed attestation counts and the signer balance.
+// Metrics are tagged the DogStatsD way (e.g. `|#network:SN_SEPOLIA`), which Datadog agents
+// and most statsd servers understand
+type Tracer struct {
+	metrics.Tracer
+	conn   net.Conn
+	prefix string
+	tags   []string
+}
+
+// New returns a tracer sending the core metrics to the statsd server at address (e.g.
+// `localhost:8125`), every one of them tagged with the network. When the prefix is empty
+// `DefaultPrefix` is used. Sending is best-effort, since statsd runs over udp
+func New(tracer metrics.Tracer, address string, prefix string, network string) (*Tracer, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to statsd at %s: %w", address, err)
+	}
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	return &Tracer{
+		Tracer: tracer,
+		conn:   conn,
+		prefix: prefix,
+		tags:   []string{tag("network", network)},
+	}, nil
+}
+
+// Close releases the connection to the statsd server
+func (t *Tracer) Close() error {
+	return t.conn.Close()
+}
+
+func (t *Tracer) RecordAttestationSubmitted() {
+	t.send(MetricSubmitted, "1", "c")
+	t.Tracer.RecordAttestationSubmitted()
+}
+
+func (t *Tracer) RecordAttestationConfirmed(txHash string) {
+	t.send(MetricConfirmed, "1", "c")
+	t.Tracer.RecordAttestationConfirmed(txHash)
+}
+
+func (t *Tracer) RecordAttestationFailure(reason string) {
+	t.send(MetricFailed, "1", "c", tag("reason", reason))
+	t.Tracer.RecordAttestationFailure(reason)
+}
+
+func (t *Tracer) UpdateSignerBalance(address string, role string, balance float64) {
+	t.send(
+		MetricSignerBalance,
+		strconv.FormatFloat(balance, 'f', -1, 64),
+		"g",
+		tag("address", address),
+		tag("address_role", role),
+	)
+	t.Tracer.UpdateSignerBalance(address, role, balance)
+}
+
+// Sends a single metric, e.g. `validator.attestation.submitted:1|c|#network:SN_SEPOLIA`.
+// A failed write is dropped like a lost udp packet would be
+func (t *Tracer) send(name string, value string, kind string, tags ...string) {
+	line := fmt.Sprintf(
+		"%s.%s:%s|%s|#%s",
+		t.prefix,
+		name,
+		value,
+		kind,
+		strings.Join(append(tags, t.tags...), ","),
+	)
+	_, _ = t.conn.Write([]byte(line))
+}
+
+// Returns the tag with the characters reserved by the statsd format replaced
+func tag(name string, value string) string {
+	return name + ":" + strings.NewReplacer("|", "_", ",", "_", "#", "_").Replace(value)
+}
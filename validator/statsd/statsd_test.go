@@ -0,0 +1,82 @@
+package statsd_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
+	"github.com/NethermindEth/starknet-staking-v2/validator/statsd"
+	"github.com/stretchr/testify/require"
+)
+
+// Listens for statsd packets, returning the server address and a function reading the next
+// packet received
+func listen(t *testing.T) (string, func() string) {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return conn.LocalAddr().String(), func() string {
+		buf := make([]byte, 1024)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		return string(buf[:n])
+	}
+}
+
+type countingTracer struct {
+	metrics.NoOpMetrics
+	submitted int
+}
+
+func (c *countingTracer) RecordAttestationSubmitted() {
+	c.submitted++
+}
+
+func TestTracer(t *testing.T) {
+	address, next := listen(t)
+	wrapped := &countingTracer{}
+	tracer, err := statsd.New(wrapped, address, "", "SN_SEPOLIA")
+	require.NoError(t, err)
+	t.Cleanup(func() { tracer.Close() })
+
+	tracer.RecordAttestationSubmitted()
+	require.Equal(t, "validator.attestation.submitted:1|c|#network:SN_SEPOLIA", next())
+	// The wrapped tracer keeps recording
+	require.Equal(t, 1, wrapped.submitted)
+
+	tracer.RecordAttestationConfirmed("0x123")
+	require.Equal(t, "validator.attestation.confirmed:1|c|#network:SN_SEPOLIA", next())
+
+	tracer.RecordAttestationFailure("not_confirmed")
+	require.Equal(
+		t, "validator.attestation.failed:1|c|#reason:not_confirmed,network:SN_SEPOLIA", next(),
+	)
+
+	tracer.UpdateSignerBalance("0x456", metrics.AddressRoleOperational, 112.5)
+	require.Equal(
+		t,
+		"validator.attestation.signer_balance:112.5|g"+
+			"|#address:0x456,address_role:operational,network:SN_SEPOLIA",
+		next(),
+	)
+
+	// Metrics not mirrored aren't sent
+	tracer.RecordAttestationMissed()
+	tracer.RecordAttestationSubmitted()
+	require.Equal(t, "validator.attestation.submitted:1|c|#network:SN_SEPOLIA", next())
+}
+
+func TestNewPrefix(t *testing.T) {
+	address, next := listen(t)
+	tracer, err := statsd.New(metrics.NewNoOpMetrics(), address, "staker", "mainnet")
+	require.NoError(t, err)
+	t.Cleanup(func() { tracer.Close() })
+
+	tracer.RecordAttestationSubmitted()
+	require.Equal(t, "staker.submitted:1|c|#network:mainnet", next())
+}
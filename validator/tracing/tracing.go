@@ -0,0 +1,222 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
+	"github.com/NethermindEth/starknet-staking-v2/validator/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	ServiceName = "starknet-staking-validator"
+
+	// Span names, one for the whole attestation and one for each of its phases
+	SpanAttestation = "attestation"
+	SpanDetect      = "attestation.detect"
+	SpanBuild       = "attestation.build"
+	SpanSubmit      = "attestation.submit"
+	SpanConfirm     = "attestation.confirm"
+)
+
+// Span attribute keys
+const (
+	AttrEpochID       = attribute.Key("epoch.id")
+	AttrAssignedBlock = attribute.Key("attestation.assigned_block")
+	AttrTxHash        = attribute.Key("attestation.tx_hash")
+)
+
+// NewProvider returns a tracer provider exporting spans through OTLP over http to the
+// endpoint (e.g. `localhost:4318`). When the endpoint is empty a no-op provider is returned
+// so tracing costs nothing. The returned function flushes and stops the exporter
+func NewProvider(ctx context.Context, endpoint string, version string) (
+	trace.TracerProvider, func(context.Context) error, error,
+) {
+	if endpoint == "" {
+		return noop.NewTracerProvider(), func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(
+		ctx, otlptracehttp.WithEndpoint(endpoint), otlptracehttp.WithInsecure(),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName(ServiceName),
+			semconv.ServiceVersion(version),
+		)),
+	)
+	return provider, provider.Shutdown, nil
+}
+
+var _ metrics.Tracer = (*Tracer)(nil)
+
+var errAttestationMissed = errors.New("attestation window ended without a confirmed attestation")
+
+// Wraps a metrics tracer, additionally turning the attestation lifecycle into OpenTelemetry
+// spans. Every attestation produces a root span with one child span per phase:
+// detect window, build transaction, submit and confirm
+type Tracer struct {
+	metrics.Tracer
+	tracer trace.Tracer
+
+	mu            sync.Mutex
+	epochID       uint64
+	assignedBlock uint64
+	txHash        string
+	ctx           context.Context
+	span          trace.Span
+}
+
+func New(tracer metrics.Tracer, provider trace.TracerProvider) *Tracer {
+	return &Tracer{
+		Tracer: tracer,
+		tracer: provider.Tracer(ServiceName),
+	}
+}
+
+func (t *Tracer) UpdateEpochInfo(epochInfo *types.EpochInfo, targetBlock uint64) {
+	t.mu.Lock()
+	t.epochID = epochInfo.EpochId
+	t.assignedBlock = targetBlock
+	t.mu.Unlock()
+
+	t.Tracer.UpdateEpochInfo(epochInfo, targetBlock)
+}
+
+func (t *Tracer) RecordAttestationDetected() {
+	t.mu.Lock()
+	ctx := t.attestation(time.Now())
+	_, span := t.tracer.Start(ctx, SpanDetect, trace.WithAttributes(t.attributes()...))
+	span.End()
+	t.mu.Unlock()
+
+	t.Tracer.RecordAttestationDetected()
+}
+
+func (t *Tracer) RecordAttestationBuilt(start time.Time, err error) {
+	t.mu.Lock()
+	t.phase(SpanBuild, start, err)
+	t.mu.Unlock()
+
+	t.Tracer.RecordAttestationBuilt(start, err)
+}
+
+func (t *Tracer) RecordAttestationInvoked(start time.Time, txHash string, err error) {
+	t.mu.Lock()
+	if txHash != "" {
+		t.txHash = txHash
+	}
+	t.phase(SpanSubmit, start, err)
+	t.mu.Unlock()
+
+	t.Tracer.RecordAttestationInvoked(start, txHash, err)
+}
+
+func (t *Tracer) RecordAttestationConfirmationLatency(d time.Duration) {
+	t.mu.Lock()
+	t.phase(SpanConfirm, time.Now().Add(-d), nil)
+	t.mu.Unlock()
+
+	t.Tracer.RecordAttestationConfirmationLatency(d)
+}
+
+func (t *Tracer) RecordAttestationConfirmed(txHash string) {
+	t.mu.Lock()
+	if txHash != "" {
+		t.txHash = txHash
+	}
+	t.end(nil)
+	t.mu.Unlock()
+
+	t.Tracer.RecordAttestationConfirmed(txHash)
+}
+
+func (t *Tracer) RecordAttestationFailure(reason string) {
+	t.mu.Lock()
+	t.end(fmt.Errorf("attestation failed: %s", reason))
+	t.mu.Unlock()
+
+	t.Tracer.RecordAttestationFailure(reason)
+}
+
+func (t *Tracer) RecordAttestationMissed() {
+	t.mu.Lock()
+	t.end(errAttestationMissed)
+	t.mu.Unlock()
+
+	t.Tracer.RecordAttestationMissed()
+}
+
+// Returns the context of the ongoing attestation span, starting it at `start` if there is
+// none. Must be called with the lock held
+func (t *Tracer) attestation(start time.Time) context.Context {
+	if t.span == nil {
+		t.ctx, t.span = t.tracer.Start(
+			context.Background(),
+			SpanAttestation,
+			trace.WithTimestamp(start),
+			trace.WithAttributes(t.attributes()...),
+		)
+	}
+	return t.ctx
+}
+
+// Records a phase of the ongoing attestation as a child span from `start` until now.
+// Must be called with the lock held
+func (t *Tracer) phase(name string, start time.Time, err error) {
+	ctx := t.attestation(start)
+	_, span := t.tracer.Start(
+		ctx, name, trace.WithTimestamp(start), trace.WithAttributes(t.attributes()...),
+	)
+	setStatus(span, err)
+	span.End()
+}
+
+// Ends the ongoing attestation span, if any. Must be called with the lock held
+func (t *Tracer) end(err error) {
+	if t.span == nil {
+		return
+	}
+	t.span.SetAttributes(t.attributes()...)
+	setStatus(t.span, err)
+	t.span.End()
+
+	t.span = nil
+	t.ctx = nil
+	t.txHash = ""
+}
+
+func (t *Tracer) attributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		AttrEpochID.Int64(int64(t.epochID)),
+		AttrAssignedBlock.Int64(int64(t.assignedBlock)),
+	}
+	if t.txHash != "" {
+		attrs = append(attrs, AttrTxHash.String(t.txHash))
+	}
+	return attrs
+}
+
+func setStatus(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+	span.SetStatus(codes.Ok, "")
+}
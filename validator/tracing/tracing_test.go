@@ -0,0 +1,130 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
+	"github.com/NethermindEth/starknet-staking-v2/validator/tracing"
+	"github.com/NethermindEth/starknet-staking-v2/validator/types"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setup() (*tracing.Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tracing.New(metrics.NewNoOpMetrics(), provider)
+	tracer.UpdateEpochInfo(&types.EpochInfo{EpochId: 5}, 120)
+	return tracer, recorder
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, attr := range span.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+	return attrs
+}
+
+func TestTracer(t *testing.T) {
+	t.Run("Confirmed attestation produces a span per phase", func(t *testing.T) {
+		tracer, recorder := setup()
+
+		tracer.RecordAttestationDetected()
+		tracer.RecordAttestationBuilt(time.Now(), nil)
+		tracer.RecordAttestationInvoked(time.Now(), "0x123", nil)
+		tracer.RecordAttestationConfirmationLatency(time.Second)
+		tracer.RecordAttestationConfirmed("0x123")
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 5)
+
+		names := make([]string, len(spans))
+		for i, span := range spans {
+			names[i] = span.Name()
+		}
+		require.Equal(t, []string{
+			tracing.SpanDetect,
+			tracing.SpanBuild,
+			tracing.SpanSubmit,
+			tracing.SpanConfirm,
+			tracing.SpanAttestation,
+		}, names)
+
+		root := spans[4]
+		for _, span := range spans[:4] {
+			require.Equal(t, root.SpanContext().SpanID(), span.Parent().SpanID())
+		}
+		require.Equal(t, codes.Ok, root.Status().Code)
+
+		attrs := attributes(root)
+		require.Equal(t, int64(5), attrs[tracing.AttrEpochID].AsInt64())
+		require.Equal(t, int64(120), attrs[tracing.AttrAssignedBlock].AsInt64())
+		require.Equal(t, "0x123", attrs[tracing.AttrTxHash].AsString())
+	})
+
+	t.Run("Failed phase and missed attestation are marked as errors", func(t *testing.T) {
+		tracer, recorder := setup()
+
+		tracer.RecordAttestationDetected()
+		tracer.RecordAttestationInvoked(time.Now(), "", errors.New("some error"))
+		tracer.RecordAttestationMissed()
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 3)
+		require.Equal(t, tracing.SpanSubmit, spans[1].Name())
+		require.Equal(t, codes.Error, spans[1].Status().Code)
+		require.Equal(t, tracing.SpanAttestation, spans[2].Name())
+		require.Equal(t, codes.Error, spans[2].Status().Code)
+		require.NotContains(t, attributes(spans[2]), tracing.AttrTxHash)
+	})
+
+	t.Run("Failed attestation ends its root span", func(t *testing.T) {
+		tracer, recorder := setup()
+
+		tracer.RecordAttestationDetected()
+		tracer.RecordAttestationFailure("not_confirmed")
+		tracer.RecordAttestationMissed()
+		tracer.RecordAttestationDetected()
+		tracer.RecordAttestationConfirmed("")
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 4)
+		require.Equal(t, tracing.SpanAttestation, spans[1].Name())
+		require.Equal(t, codes.Error, spans[1].Status().Code)
+		require.Contains(t, spans[1].Status().Description, "not_confirmed")
+		require.Equal(t, tracing.SpanAttestation, spans[3].Name())
+		require.Equal(t, codes.Ok, spans[3].Status().Code)
+		require.NotEqual(t, spans[1].SpanContext().TraceID(), spans[3].SpanContext().TraceID())
+	})
+
+	t.Run("Next attestation starts a new root span", func(t *testing.T) {
+		tracer, recorder := setup()
+
+		tracer.RecordAttestationDetected()
+		tracer.RecordAttestationConfirmed("")
+		tracer.RecordAttestationDetected()
+		tracer.RecordAttestationConfirmed("")
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 4)
+		require.NotEqual(t, spans[1].SpanContext().TraceID(), spans[3].SpanContext().TraceID())
+	})
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Run("No endpoint returns a no-op provider", func(t *testing.T) {
+		provider, shutdown, err := tracing.NewProvider(context.Background(), "", "0.0.0")
+		require.NoError(t, err)
+
+		_, span := provider.Tracer("test").Start(context.Background(), "span")
+		require.False(t, span.SpanContext().IsValid())
+		require.NoError(t, shutdown(context.Background()))
+	})
+}
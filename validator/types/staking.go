@@ -16,6 +16,8 @@ type PrepareAttest struct {
 // Represents an event for the dispatcher to invoke an attest transaction
 type DoAttest struct {
 	BlockHash BlockHash
+	// First block past the attestation window
+	WindowEnd BlockNumber
 }
 
 // Used by the validator to keep track of the starknet attestation window
@@ -26,6 +28,15 @@ type AttestInfo struct {
 	WindowEnd       BlockNumber
 }
 
+// Returns how many blocks are left from the head block until the attestation window
+// closes, or zero if it is already closed
+func (a *AttestInfo) BlocksUntilWindowClose(head uint64) uint64 {
+	if head >= uint64(a.WindowEnd) {
+		return 0
+	}
+	return uint64(a.WindowEnd) - head
+}
+
 // Used by the validator to keep track of the current epoch info
 type EpochInfo struct {
 	StakerAddress Address         `json:"staker_address"`
@@ -0,0 +1,18 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/NethermindEth/starknet-staking-v2/validator/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocksUntilWindowClose(t *testing.T) {
+	attestInfo := types.AttestInfo{TargetBlock: 100, WindowStart: 111, WindowEnd: 116}
+
+	require.Equal(t, uint64(26), attestInfo.BlocksUntilWindowClose(90))
+	require.Equal(t, uint64(5), attestInfo.BlocksUntilWindowClose(111))
+	require.Equal(t, uint64(1), attestInfo.BlocksUntilWindowClose(115))
+	require.Equal(t, uint64(0), attestInfo.BlocksUntilWindowClose(116))
+	require.Equal(t, uint64(0), attestInfo.BlocksUntilWindowClose(200))
+}
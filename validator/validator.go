@@ -3,8 +3,10 @@ package validator
 import (
 	"context"
 	"strconv"
+	"sync/atomic"
 	"time"
 
+	"github.com/NethermindEth/juno/core/felt"
 	"github.com/NethermindEth/juno/utils"
 	"github.com/NethermindEth/starknet-staking-v2/validator/config"
 	"github.com/NethermindEth/starknet-staking-v2/validator/metrics"
@@ -24,6 +26,12 @@ type Validator struct {
 
 	// Used to initiate a websocket connection later on
 	wsProvider string
+	// Url of the external signer, empty when signing internally
+	signerURL string
+	// Account receiving the staking rewards, nil when its balance isn't tracked
+	rewardsAddress *types.Address
+	// Class hash the signer account is expected to have, nil when it isn't checked
+	accountClassHash *felt.Felt
 }
 
 func New(
@@ -65,11 +73,28 @@ func New(
 		logger.Info("Using internal signer")
 	}
 
+	var rewardsAddress *types.Address
+	if config.Signer.RewardsAddress != "" {
+		address := types.AddressFromString(config.Signer.RewardsAddress)
+		rewardsAddress = &address
+	}
+
+	var accountClassHash *felt.Felt
+	if config.Signer.AccountClassHash != "" {
+		accountClassHash, err = new(felt.Felt).SetString(config.Signer.AccountClassHash)
+		if err != nil {
+			return Validator{}, errors.Errorf("cannot parse the account class hash: %w", err)
+		}
+	}
+
 	return Validator{
-		provider:   provider,
-		signer:     signer,
-		logger:     logger,
-		wsProvider: config.Provider.Ws,
+		provider:         provider,
+		signer:           signer,
+		logger:           logger,
+		wsProvider:       config.Provider.Ws,
+		signerURL:        config.Signer.ExternalURL,
+		rewardsAddress:   rewardsAddress,
+		accountClassHash: accountClassHash,
 	}, nil
 }
 
@@ -89,21 +114,36 @@ func (v *Validator) ChainID() string {
 func (v *Validator) Attest(
 	ctx context.Context, maxRetries types.Retries, balanceThreshold float64, tracer metrics.Tracer,
 ) error {
-	// Initial check of the account balance
-	go CheckBalance(v.signer, balanceThreshold, &v.logger, tracer)
+	// Every request the signer does to the node is recorded
+	var signer signerP.Signer = signerP.NewTracedSigner(v.signer, tracer)
+
+	tracer.UpdateSignerBalanceThreshold(balanceThreshold)
+
+	// Pre-flight checks of the node network and the signer account
+	CheckChainID(signer, v.ChainID(), &v.logger, tracer)
+	CheckSignerAccount(signer, v.accountClassHash, &v.logger, tracer)
+
+	// Initial check of the account balance, which stays unknown until then
+	tracer.MarkSignerBalanceUnknown(signer.Address().String(), metrics.AddressRoleOperational)
+	go CheckBalance(signer, balanceThreshold, &v.logger, tracer)
+	if v.rewardsAddress != nil {
+		tracer.MarkSignerBalanceUnknown(v.rewardsAddress.String(), metrics.AddressRoleRewards)
+		go CheckRewardsBalance(signer, v.rewardsAddress, &v.logger, tracer)
+	}
 
 	// Create the event dispatcher
 	dispatcher := NewEventDispatcher[signerP.Signer]()
+	dispatcher.RewardsAddress = v.rewardsAddress
 	wg := conc.NewWaitGroup()
 	wg.Go(func() {
-		dispatcher.Dispatch(v.signer, balanceThreshold, &v.logger, tracer)
+		dispatcher.Dispatch(signer, balanceThreshold, &v.logger, tracer)
 		v.logger.Debug("Dispatch method finished")
 	})
 	defer wg.Wait()
 	defer close(dispatcher.PrepareAttest)
 
 	return RunBlockHeaderWatcher(
-		ctx, v.wsProvider, &v.logger, v.signer, &dispatcher, maxRetries, wg, tracer,
+		ctx, v.wsProvider, &v.logger, signer, &dispatcher, maxRetries, wg, tracer,
 	)
 }
 
@@ -147,15 +187,24 @@ func RunBlockHeaderWatcher[S signerP.Signer](
 			}
 		})
 
-		select {
-		case err := <-clientSubscription.Err():
-			logger.Errorw("client subscription error", "error", err.Error())
-			logger.Debug("Ending headers subscription, closing websocket connection and retrying...")
-			cleanUp(wsProvider, headersFeed)
-		case err := <-stopProcessingHeaders:
-			logger.Errorw("processing block headers", "error", err.Error())
-			cleanUp(wsProvider, headersFeed)
-			return err
+	subscription:
+		for {
+			select {
+			// Reorgs are notified apart from the headers, and must be consumed so the
+			// subscription doesn't stall
+			case reorg := <-clientSubscription.Reorg():
+				RecordReorg(logger, reorg, tracer)
+			case err := <-clientSubscription.Err():
+				logger.Errorw("client subscription error", "error", err.Error())
+				logger.Debug("Ending headers subscription, closing websocket connection and retrying...")
+				cleanUp(wsProvider, headersFeed)
+				tracer.RecordRPCReconnect()
+				break subscription
+			case err := <-stopProcessingHeaders:
+				logger.Errorw("processing block headers", "error", err.Error())
+				cleanUp(wsProvider, headersFeed)
+				return err
+			}
 		}
 	}
 }
@@ -170,19 +219,32 @@ func ProcessBlockHeaders[Account signerP.Signer](
 ) error {
 	noEpochSwitch := func(*types.EpochInfo, *types.EpochInfo) bool { return true }
 	epochInfo, attestInfo, err := FetchEpochAndAttestInfoWithRetry(
-		account, logger, nil, noEpochSwitch, maxRetries, "at app startup",
+		account, logger, nil, noEpochSwitch, maxRetries, "at app startup", tracer,
 	)
 	if err != nil {
 		return err
 	}
+	tracer.SetReady(true)
 
 	SetTargetBlockHashIfExists(account, logger, &attestInfo)
-	tracer.UpdateEpochInfo(&epochInfo, attestInfo.TargetBlock.Uint64())
+	RecordEpochInfo(account, &epochInfo, &attestInfo, tracer)
+	CheckValidatorActive(account, logger, tracer)
+
+	// The block lag is checked in the background so a slow node never delays attesting. A
+	// check is skipped while the previous one is still in flight
+	var lagCheckInFlight atomic.Bool
 
 	for block := range headersFeed {
+		processingStart := time.Now()
 		logger.Infof("Block %d received", block.Number)
 		logger.Debugw("Block header information", "block header", block)
-		tracer.UpdateLatestBlockNumber(block.Number)
+		tracer.UpdateLatestBlockNumber(block.Number, time.Unix(int64(block.Timestamp), 0))
+		if lagCheckInFlight.CompareAndSwap(false, true) {
+			go func(processedBlock uint64) {
+				defer lagCheckInFlight.Store(false)
+				UpdateBlockLag(account, logger, processedBlock, tracer)
+			}(block.Number)
+		}
 
 		// todo(rdr): look for some nice way of refactoring this if/else blocks
 		if block.Number >= uint64(epochInfo.StartingBlock)+epochInfo.EpochLen {
@@ -195,13 +257,17 @@ func ProcessBlockHeaders[Account signerP.Signer](
 				CorrectEpochSwitch,
 				maxRetries,
 				strconv.FormatUint(prevEpochInfo.EpochId+1, 10),
+				tracer,
 			)
 			if err != nil {
 				return err
 			}
 			// Update epoch info metrics
-			tracer.UpdateEpochInfo(&epochInfo, attestInfo.TargetBlock.Uint64())
+			RecordEpochInfo(account, &epochInfo, &attestInfo, tracer)
+			CheckValidatorActive(account, logger, tracer)
 		}
+		tracer.UpdateBlocksUntilWindowClose(attestInfo.BlocksUntilWindowClose(block.Number))
+
 		if uint64(attestInfo.TargetBlock) == block.Number {
 			attestInfo.TargetBlockHash = types.BlockHash(*block.Hash)
 			logger.Infow(
@@ -229,15 +295,49 @@ func ProcessBlockHeaders[Account signerP.Signer](
 			types.BlockNumber(block.Number) < attestInfo.WindowEnd {
 			dispatcher.DoAttest <- types.DoAttest{
 				BlockHash: attestInfo.TargetBlockHash,
+				WindowEnd: attestInfo.WindowEnd,
 			}
 		} else if types.BlockNumber(block.Number) == attestInfo.WindowEnd {
 			dispatcher.EndOfWindow <- struct{}{}
 		}
+		tracer.RecordBlockProcessingDuration(time.Since(processingStart))
 	}
 
 	return nil
 }
 
+// Records the epoch related metrics, including the validator stake for the epoch
+func RecordEpochInfo[Account signerP.Signer](
+	account Account,
+	epochInfo *types.EpochInfo,
+	attestInfo *types.AttestInfo,
+	tracer metrics.Tracer,
+) {
+	tracer.UpdateEpochInfo(epochInfo, attestInfo.TargetBlock.Uint64())
+	tracer.UpdateAttestationWindow(uint64(attestInfo.WindowEnd - attestInfo.TargetBlock))
+	tracer.RecordAttestationTarget(
+		epochInfo.EpochId, epochInfo.StartingBlock.Uint64(), attestInfo.TargetBlock.Uint64(),
+	)
+	stake := types.Balance(*epochInfo.Stake.Big())
+	tracer.UpdateValidatorStake(account.Address().String(), stake.Strk())
+}
+
+// Records how far behind the node's latest block the processed block is
+func UpdateBlockLag[Account signerP.Signer](
+	account Account, logger *utils.ZapLogger, processedBlock uint64, tracer metrics.Tracer,
+) {
+	latestBlock, err := account.BlockNumber()
+	if err != nil {
+		logger.Debugw("Failed to fetch the node's latest block number", "error", err.Error())
+		return
+	}
+	if latestBlock < processedBlock {
+		// The node's view can lag behind the header subscription for a moment
+		latestBlock = processedBlock
+	}
+	tracer.UpdateBlockLag(latestBlock - processedBlock)
+}
+
 func SetTargetBlockHashIfExists[Account signerP.Signer](
 	account Account,
 	logger *utils.ZapLogger,
@@ -268,11 +368,22 @@ func FetchEpochAndAttestInfoWithRetry[Signer signerP.Signer](
 	isEpochSwitchCorrect func(prevEpoch *types.EpochInfo, newEpoch *types.EpochInfo) bool,
 	maxRetries types.Retries,
 	newEpochId string,
+	tracer metrics.Tracer,
 ) (types.EpochInfo, types.AttestInfo, error) {
 	// storing the initial value for error reporting
 	totalRetryAmount := maxRetries.String()
 
-	newEpoch, newAttestInfo, err := signerP.FetchEpochAndAttestInfo(signer, logger)
+	fetch := func() (types.EpochInfo, types.AttestInfo, error) {
+		start := time.Now()
+		epochInfo, attestInfo, err := signerP.FetchEpochAndAttestInfo(signer, logger)
+		tracer.RecordEpochFetchDuration(time.Since(start))
+		if err != nil {
+			tracer.RecordEpochFetchFailure()
+		}
+		return epochInfo, attestInfo, err
+	}
+
+	newEpoch, newAttestInfo, err := fetch()
 
 	for (err != nil || !isEpochSwitchCorrect(prevEpoch, &newEpoch)) && !maxRetries.IsZero() {
 		if err != nil {
@@ -284,7 +395,7 @@ func FetchEpochAndAttestInfoWithRetry[Signer signerP.Signer](
 
 		Sleep(time.Second)
 
-		newEpoch, newAttestInfo, err = signerP.FetchEpochAndAttestInfo(signer, logger)
+		newEpoch, newAttestInfo, err = fetch()
 		maxRetries.Sub()
 	}
 